@@ -3,6 +3,7 @@ package ast
 import (
 	"bytes"
 	"github.com/hmwri/peridot/token"
+	"math/big"
 	"strings"
 )
 
@@ -75,10 +76,66 @@ func (m *Make) String() string {
 	return out.String()
 }
 
+//Const Const node(Statements)
+type Const struct {
+	Token token.Token
+	Name  *Identifier
+	Value Expression
+}
+
+func (c *Const) statementNode()       {}
+func (c *Const) TokenLiteral() string { return c.Token.Literal }
+func (c *Const) String() string {
+	var out bytes.Buffer
+	out.WriteString(c.TokenLiteral() + " ")
+	out.WriteString(c.Name.String())
+	out.WriteString(" = ")
+	if c.Value != nil {
+		out.WriteString(c.Value.String())
+	}
+	return out.String()
+}
+
+//TypeDecl Type declaration node(type Point { x, y })
+type TypeDecl struct {
+	Token  token.Token
+	Name   *Identifier
+	Fields []*Identifier
+}
+
+func (td *TypeDecl) statementNode()       {}
+func (td *TypeDecl) TokenLiteral() string { return td.Token.Literal }
+func (td *TypeDecl) String() string {
+	fields := []string{}
+	for _, f := range td.Fields {
+		fields = append(fields, f.String())
+	}
+	return td.TokenLiteral() + " " + td.Name.String() + " { " + strings.Join(fields, ", ") + " }"
+}
+
+//Import Import node(import "utils.pri" as u)
+type Import struct {
+	Token token.Token
+	Path  string
+	//Name name of namespace(alias or file name without extension)
+	Name  *Identifier
+	Alias bool
+}
+
+func (im *Import) statementNode()       {}
+func (im *Import) TokenLiteral() string { return im.Token.Literal }
+func (im *Import) String() string {
+	if im.Alias {
+		return im.TokenLiteral() + " \"" + im.Path + "\" as " + im.Name.String()
+	}
+	return im.TokenLiteral() + " \"" + im.Path + "\""
+}
+
 //Return node(Statements)
 type Return struct {
 	Token token.Token
 	Value Expression //return value
+	Tail  bool       //If true,return is in tail position of function body(return f() can be tail call)
 }
 
 func (r *Return) statementNode()       {}
@@ -97,15 +154,35 @@ func (r *Return) String() string {
 //Stop node(Statements)
 type Stop struct {
 	Token token.Token
+	Label *Identifier
 }
 
 func (s *Stop) statementNode()       {}
 func (s *Stop) TokenLiteral() string { return s.Token.Literal }
 
 func (s *Stop) String() string {
+	if s.Label != nil {
+		return "Stop " + s.Label.String()
+	}
 	return "Stop"
 }
 
+//Next node(Statements)
+type Next struct {
+	Token token.Token
+	Label *Identifier
+}
+
+func (n *Next) statementNode()       {}
+func (n *Next) TokenLiteral() string { return n.Token.Literal }
+
+func (n *Next) String() string {
+	if n.Label != nil {
+		return "Next " + n.Label.String()
+	}
+	return "Next"
+}
+
 //Identifier Identifier node(Expressions)
 type Identifier struct {
 	Token token.Token
@@ -137,6 +214,8 @@ func (e *ExpressionStatement) String() string {
 type Int struct {
 	Token token.Token
 	Value int64
+	//Big literal which doesn't fit in int64(nil if it fits)
+	Big *big.Int
 }
 
 func (i *Int) expressionNode()      {}
@@ -169,6 +248,17 @@ func (b *Bool) String() string {
 	return b.Token.Literal
 }
 
+//Nil node(nil literal)
+type Nil struct {
+	Token token.Token
+}
+
+func (n *Nil) expressionNode()      {}
+func (n *Nil) TokenLiteral() string { return n.Token.Literal }
+func (n *Nil) String() string {
+	return n.Token.Literal
+}
+
 //Prefix node
 type Prefix struct {
 	Token    token.Token
@@ -207,6 +297,8 @@ type If struct {
 	Token       token.Token
 	Condition   Expression
 	Consequence *BlockStmt
+	//ElseIf Next if of "else if" chain
+	ElseIf      *If
 	Alternative *BlockStmt
 }
 
@@ -214,17 +306,86 @@ func (If *If) expressionNode()      {}
 func (If *If) TokenLiteral() string { return If.Token.Literal }
 func (If *If) String() string {
 	var out bytes.Buffer
-	out.WriteString("if" + If.Condition.String() + " " + If.Consequence.String())
-	if If.Alternative != nil {
-		out.WriteString("else ")
-		out.WriteString(If.Alternative.String())
+	out.WriteString("if " + If.Condition.String() + " {" + If.Consequence.String() + "}")
+	if If.ElseIf != nil {
+		out.WriteString(" else ")
+		out.WriteString(If.ElseIf.String())
+	} else if If.Alternative != nil {
+		out.WriteString(" else {")
+		out.WriteString(If.Alternative.String() + "}")
+	}
+	return out.String()
+}
+
+//Try expression Node(try {} catch e {})
+type Try struct {
+	Token   token.Token
+	Block   *BlockStmt
+	Name    *Identifier
+	Handler *BlockStmt
+}
+
+func (tr *Try) expressionNode()      {}
+func (tr *Try) TokenLiteral() string { return tr.Token.Literal }
+func (tr *Try) String() string {
+	var out bytes.Buffer
+	out.WriteString("try {" + tr.Block.String() + "} catch ")
+	if tr.Name != nil {
+		out.WriteString(tr.Name.String() + " ")
+	}
+	out.WriteString("{" + tr.Handler.String() + "}")
+	return out.String()
+}
+
+//Match expression Node
+type Match struct {
+	Token token.Token
+	Value Expression
+	Arms  []*MatchArm
+}
+
+func (mt *Match) expressionNode()      {}
+func (mt *Match) TokenLiteral() string { return mt.Token.Literal }
+func (mt *Match) String() string {
+	var out bytes.Buffer
+	arms := []string{}
+	for _, a := range mt.Arms {
+		arms = append(arms, a.String())
 	}
+	out.WriteString("match " + mt.Value.String() + " {")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+//MatchArm Arm of match(patterns if guard => body)
+type MatchArm struct {
+	Token    token.Token
+	Patterns []Expression
+	Guard    Expression
+	Body     *BlockStmt
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+	patterns := []string{}
+	for _, p := range ma.Patterns {
+		if p != nil {
+			patterns = append(patterns, p.String())
+		}
+	}
+	out.WriteString(strings.Join(patterns, ", "))
+	if ma.Guard != nil {
+		out.WriteString(" if " + ma.Guard.String())
+	}
+	out.WriteString(" => {" + ma.Body.String() + "}")
 	return out.String()
 }
 
 //Loop expression Node
 type Loop struct {
 	Token     token.Token
+	Label     *Identifier
 	Condition Expression
 	Process   *BlockStmt
 }
@@ -233,15 +394,61 @@ func (lp *Loop) statementNode()       {}
 func (lp *Loop) TokenLiteral() string { return lp.Token.Literal }
 func (lp *Loop) String() string {
 	var out bytes.Buffer
-	out.WriteString("loop" + lp.Condition.String() + " " + lp.Process.String())
+	out.WriteString("loop")
+	if lp.Label != nil {
+		out.WriteString(" " + lp.Label.String() + ":")
+	}
+	out.WriteString(lp.Condition.String() + " " + lp.Process.String())
 	return out.String()
 }
 
+//ForEach loop(loop x in array / loop i, x in array) Node
+type ForEach struct {
+	Token    token.Token
+	Label    *Identifier
+	Index    *Identifier
+	Value    *Identifier
+	Iterable Expression
+	Process  *BlockStmt
+}
+
+func (fe *ForEach) statementNode()       {}
+func (fe *ForEach) TokenLiteral() string { return fe.Token.Literal }
+func (fe *ForEach) String() string {
+	var out bytes.Buffer
+	out.WriteString("loop ")
+	if fe.Label != nil {
+		out.WriteString(fe.Label.String() + ": ")
+	}
+	if fe.Index != nil {
+		out.WriteString(fe.Index.String() + ", ")
+	}
+	out.WriteString(fe.Value.String() + " in " + fe.Iterable.String() + " " + fe.Process.String())
+	return out.String()
+}
+
+//Range expression(start..end) Node
+type Range struct {
+	Token token.Token
+	Start Expression
+	End   Expression
+}
+
+func (rg *Range) expressionNode()      {}
+func (rg *Range) TokenLiteral() string { return rg.Token.Literal }
+func (rg *Range) String() string {
+	return "(" + rg.Start.String() + ".." + rg.End.String() + ")"
+}
+
 //Function expression Node
 type Function struct {
 	Token      token.Token
 	Name       *Identifier
 	Parameters []*Identifier
+	Defaults   []Expression //default value of each parameter(nil if no default)
+	Rest       *Identifier  //variadic parameter(...name)
+	Receiver   *Identifier  //receiver of method(func (p Point) name() {})
+	RecvType   *Identifier  //type of receiver
 	Process    *BlockStmt
 }
 
@@ -249,15 +456,15 @@ func (fn *Function) expressionNode()      {}
 func (fn *Function) TokenLiteral() string { return fn.Token.Literal }
 func (fn *Function) String() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range fn.Parameters {
-		params = append(params, p.String())
-	}
+	params := ParamStrings(fn.Parameters, fn.Defaults, fn.Rest)
 	name := ""
 	if fn.Name != nil {
 		name = fn.Name.String()
 	}
 	out.WriteString(fn.TokenLiteral())
+	if fn.Receiver != nil {
+		out.WriteString(" (" + fn.Receiver.String() + " " + fn.RecvType.String() + ")")
+	}
 	out.WriteString(" " + name + " ")
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ","))
@@ -266,13 +473,39 @@ func (fn *Function) String() string {
 	return out.String()
 }
 
+//ParamStrings parameters to strings(name, name = default, ...rest)
+func ParamStrings(params []*Identifier, defaults []Expression, rest *Identifier) []string {
+	strs := []string{}
+	for i, p := range params {
+		if i < len(defaults) && defaults[i] != nil {
+			strs = append(strs, p.String()+" = "+defaults[i].String())
+			continue
+		}
+		strs = append(strs, p.String())
+	}
+	if rest != nil {
+		strs = append(strs, "..."+rest.String())
+	}
+	return strs
+}
+
 //Call expression Node
 type Call struct {
 	Token     token.Token
 	Function  Expression
 	Arguments []Expression
+	Named     []*NamedArg
 }
 
+//NamedArg argument with parameter name(name = value)
+type NamedArg struct {
+	Token token.Token
+	Name  *Identifier
+	Value Expression
+}
+
+func (na *NamedArg) String() string { return na.Name.String() + " = " + na.Value.String() }
+
 func (cl *Call) expressionNode()      {}
 func (cl *Call) TokenLiteral() string { return cl.Token.Literal }
 func (cl *Call) String() string {
@@ -285,6 +518,9 @@ func (cl *Call) String() string {
 
 		}
 	}
+	for _, na := range cl.Named {
+		args = append(args, na.String())
+	}
 	_, ok := cl.Function.(*Function)
 	_, ok2 := cl.Function.(*Identifier)
 	if ok || ok2 {
@@ -302,7 +538,9 @@ func (cl *Call) String() string {
 type Assign struct {
 	Token token.Token
 	Name  *Identifier
-	Value Expression
+	//Operator Operator of compound assignment(x += 1 is "+",x = 1 is "")
+	Operator string
+	Value    Expression
 }
 
 func (a *Assign) expressionNode()      {}
@@ -311,13 +549,35 @@ func (a *Assign) TokenLiteral() string { return a.Token.Literal }
 func (a *Assign) String() string {
 	var out bytes.Buffer
 	out.WriteString(a.Name.String())
-	out.WriteString(" = ")
+	out.WriteString(" " + a.Operator + "= ")
 	if a.Value != nil {
 		out.WriteString(a.Value.String())
 	}
 	return out.String()
 }
 
+//IndexAssign Assign to element node(arr[i] = value)
+type IndexAssign struct {
+	Token  token.Token
+	Target *Index
+	//Operator Operator of compound assignment(arr[i] += 1 is "+",arr[i] = 1 is "")
+	Operator string
+	Value    Expression
+}
+
+func (ia *IndexAssign) expressionNode()      {}
+func (ia *IndexAssign) TokenLiteral() string { return ia.Token.Literal }
+
+func (ia *IndexAssign) String() string {
+	var out bytes.Buffer
+	out.WriteString(ia.Target.String())
+	out.WriteString(" " + ia.Operator + "= ")
+	if ia.Value != nil {
+		out.WriteString(ia.Value.String())
+	}
+	return out.String()
+}
+
 //Array expression Node
 type Array struct {
 	Token    token.Token
@@ -358,6 +618,40 @@ func (ix *Index) String() string {
 	return out.String()
 }
 
+//Field Field access node(p.x)
+type Field struct {
+	Token token.Token
+	Left  Expression
+	Name  *Identifier
+}
+
+func (fd *Field) expressionNode()      {}
+func (fd *Field) TokenLiteral() string { return fd.Token.Literal }
+func (fd *Field) String() string {
+	return "(" + fd.Left.String() + "." + fd.Name.String() + ")"
+}
+
+//FieldAssign Field assignment node(p.x = value)
+type FieldAssign struct {
+	Token  token.Token
+	Target *Field
+	//Operator Operator of compound assignment(p.x += 1 is "+",p.x = 1 is "")
+	Operator string
+	Value    Expression
+}
+
+func (fa *FieldAssign) expressionNode()      {}
+func (fa *FieldAssign) TokenLiteral() string { return fa.Token.Literal }
+func (fa *FieldAssign) String() string {
+	var out bytes.Buffer
+	out.WriteString(fa.Target.String())
+	out.WriteString(" " + fa.Operator + "= ")
+	if fa.Value != nil {
+		out.WriteString(fa.Value.String())
+	}
+	return out.String()
+}
+
 type BlockStmt struct {
 	Token      token.Token
 	Statements []Statement
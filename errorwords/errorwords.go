@@ -31,6 +31,17 @@ func Jerror() {
 	Err[122] = "[%d行目]'%v'は適切な演算子ではありません！\n>>もしかして'=='?"
 
 	Err[130] = "[%d行目]全角スペース(　)は使わないでください！"
+	Err[140] = "[%d行目]%vはloopの中でのみ使えます！"
+	Err[141] = "[%d行目]'%v'という名前のloopが見つかりません！例:loop %v: 10 {}"
+	Err[150] = "[%d行目]引数'%v'が2回書かれています"
+	Err[151] = "[%d行目]可変長引数(...%v)は最後の引数にしてください"
+	Err[152] = "[%d行目]デフォルト値のない引数'%v'は、デフォルト値のある引数より前に書いてください"
+	Err[153] = "[%d行目]名前付き引数(名前 = 値)の後に普通の引数は書けません"
+	Err[154] = "[%d行目]フィールド'%v'が2回書かれています"
+	Err[155] = "[%d行目]ファイル名'%v'はそのまま名前として使えません。import \"%v\" as 名前 のように名前をつけてください"
+	Err[160] = "[%d行目]'%v'は定数(const)なので値を変更できません(実行前に検出)"
+	Err[170] = "[%d行目]'%v'は%v行目のブロック({}の中)で作られた変数なので、ブロックの外では使えません。ブロックの前でmakeしてください(古い動作にするには pri -s ファイル名)"
+	Err[171] = "[%d行目]ブロック内のmakeで、外側の変数'%v'とは別の新しい'%v'を作っています。外側の変数を変更するなら「%v = 値」と書いてください"
 	Err[200] = "[＞%d＜][エラー]"
 	Err[201] = "[%d行目]前置演算子エラー。'%v'は不適です。値の前に置けるのは-と!のみです"
 	Err[202] = "[%d行目]マイナスの後に整数、少数以外を置くことはできません。"
@@ -38,32 +49,73 @@ func Jerror() {
 	Err[204] = "[%d行目]式の記号が不適切です。'%v'は不適です。数値の間で使えるのは[+,-,*,/,<,<=,>,>=,!=,==,and,or]のみです"
 	Err[205] = "[%d行目]式の記号が不適切です。'%v'は不適です。真偽値や式の間で使えるのは[!=,==,and,or]のみです"
 	Err[206] = "[%d行目]%vの左側が不適切な値です。"
-	Err[207] = "[%d行目]文字列の操作,比較につかえるのは'+','==','!='のみです"
+	Err[207] = "[%d行目]文字列の操作,比較につかえるのは'+','==','!=','<','>','<=','>='のみです"
 	Err[208] = "[%d行目]'%v'は少数には使用できません"
+	Err[209] = "[%d行目]0で割ることはできません(%v)"
 	Err[210] = "[%d行目]%vはまだ定義されていません。変数:make 名前 = 値,関数:func 名前 (引数){}という形で定義してください"
-	Err[211] = "[%d行目]変数にnil(空)を代入できません"
+	Err[212] = "[%d行目]'%v'は定数(const)なので値を変更できません"
+	Err[213] = "[%d行目]'%v'は組み込み関数の名前なので、変数名や関数名には使えません"
+	Err[214] = "[%d行目]nil(値なし)に'%v'は使えません。nilに使えるのは[==,!=]のみです"
+	Err[215] = "[%d行目]%vと%vは大小を比べられません。大小を比べられるのは数どうし,文字列どうし,配列どうしのみです"
 	Err[220] = "[%d行目]%vの条件式が不適切です"
 	Err[230] = "[%d行目]名前がついた関数は変数に代入できません(関数名:%v)"
 	Err[231] = "[%d行目]呼び出したものが関数ではありません(関数ではない:%v)"
 	Err[232] = "[%d行目]引数の数が不適切です(呼び出し側:%v個,関数側:%v個)"
+	Err[233] = "[%d行目]関数%vには'%v'という引数はありません"
+	Err[234] = "[%d行目]関数%vの引数'%v'に値が2回渡されています"
+	Err[235] = "[%d行目]関数%vの引数'%v'に値が渡されていません"
+	Err[236] = "[%d行目]組み込み関数には名前付き引数(%v = 値)は使えません"
+	Err[240] = "[%d行目]matchの値(%v)に一致する候補がありません。最後に _ => を書くと、どれにも一致しなかったときの処理を書けます"
+	Err[250] = "[%d行目]%vにはフィールド'%v'がありません"
+	Err[251] = "[%d行目]%vのフィールドは%v個ですが、%v個の値が渡されています"
+	Err[252] = "[%d行目]%vのフィールド'%v'に値が渡されていません"
+	Err[253] = "[%d行目]%vのフィールド'%v'に値が2回渡されています"
+	Err[254] = "[%d行目]%vは'.'でフィールドを取り出せません"
+	Err[255] = "[%d行目]メソッドの型'%v'が見つかりません。先にtype %v { ... }で型を作ってください"
+	Err[256] = "[%d行目]型%vにはフィールド'%v'があるので、同じ名前のメソッドは作れません"
+	Err[257] = "[%d行目]モジュール%vには'%v'がありません"
+	Err[260] = "[%d行目]importが循環しています(%v)"
+	Err[261] = "[%d行目]importするファイル'%v'が見つかりません"
+	Err[262] = "[%d行目]importしたファイル'%v'に文法のエラーがあります:%v"
+	Err[263] = "[%d行目]標準ライブラリ'%v'はありません(使えるもの:%v)。ファイルをimportするときは\"%v.pri\"のように.priをつけてください"
 	Err[300] = "[%d行目]組み込み関数:%vの引数は%v個である必要があります"
 	Err[301] = "[%d行目]組み込み関数:%vの第一引数は%vである必要があります"
 	Err[302] = "[%d行目]組み込み関数:ADDの第一引数は配列である必要があります"
-	Err[303] = "[%d行目]組み込み関数:ADD>同じ配列を追加することはできません"
+	Err[303] = "[%d行目]組み込み関数:ADD>配列をそれ自身の中に追加することはできません"
 	Err[304] = "[%d行目]組み込み関数:DELETE>対応する値が見つかりません。DELETEの第２引数は%vである必要があります"
 	Err[305] = "[%d行目]組み込み関数:SLICEの第1,第2引数は整数である必要があります"
 	Err[306] = "[%d行目]組み込み関数:SLICE>対応する値が見つかりません。SLICEの第%v引数は%vである必要があります"
 	Err[307] = "[%d行目]組み込み関数:%vの色指定は整数の配列[赤の量,緑の量,青の量,透明度(任意)]で行ってください"
 	Err[308] = "[%d行目]組み込み関数:%vの色指定は整数値(255以下)で行ってください"
 	Err[309] = "[%d行目]組み込み関数:%vの第%v引数は%vである必要があります"
+	Err[310] = "[%d行目]組み込み関数:FROMJSON>JSONの%v行目が不正です(%v)"
+	Err[311] = "[%d行目]組み込み関数:TOJSON>%vはJSONに変換できません"
+	Err[312] = "[%d行目]組み込み関数:%v>CSVの%v行目が不正です(%v)"
+	Err[313] = "[%d行目]組み込み関数:%v>ファイル'%v'を%vできません"
+	Err[314] = "[%d行目]組み込み関数:%v>各行は配列または連想配列である必要があります"
+	Err[315] = "[%d行目]組み込み関数:%v>{}の数(%v個)と値の数(%v個)が一致しません"
+	Err[316] = "[%d行目]組み込み関数:%v>書式'%v'が不正です。例:{},{:.2f},{:>8}"
+	Err[317] = "[%d行目]組み込み関数:%v>書式'%v'は%vには使えません"
+	Err[318] = "[%d行目]組み込み関数:PARSETIME>'%v'を日時に変換できません(書式:%v)"
+	Err[319] = "[%d行目]組み込み関数:%v>正規表現'%v'が不正です(%v)"
 	Err[400] = "[%d行目]配列から値を取り出せませんでした。%vはサポートしていません"
 	Err[401] = "[%d行目]配列から値を取り出せませんでした。添字は整数にしてください。例:Array[1]"
 	Err[402] = "[%d行目]配列から値を取り出せませんでした。添字は0以上にしてください。例:Array[1]"
 	Err[403] = "[%d行目]配列から値を取り出せませんでした。[ %v ]に対応する値がみつかりません。(添字は%v以下である必要があります)"
+	Err[404] = "[%d行目]連想配列から値を取り出せませんでした。キー\"%v\"がみつかりません"
+	Err[405] = "[%d行目]連想配列から値を取り出せませんでした。添字は文字列にしてください。例:Map[\"name\"]"
+	Err[406] = "[%d行目]値を代入できませんでした。%vの要素は変更できません"
+	Err[407] = "[%d行目]値を代入できませんでした。%vをそれ自身の中に入れることはできません"
 	Err[502] = "[%d行目]文字列から文字を取り出せませんでした。添字は1以上にしてください。例:'HELLO'[1]"
 	Err[503] = "[%d行目]文字列から文字を取り出せませんでした。[ %v ]に対応する文字がみつかりません。(添字は%v以下である必要があります)"
 
+	Err[299] = "[%d行目付近]予期しないエラーが発生しました(%v)"
+
+	Err[900] = "[%d行目]%v"
+
 	Err[500] = "[%d行目]ループの条件に%vは対応していません"
+	Err[501] = "[%d行目]loop 変数 in の後に%vは使えません。使えるのは配列,文字列,連想配列,範囲(1..10)のみです"
+	Err[504] = "[%d行目]範囲(始め..終わり)の始めと終わりは整数にしてください"
 
 }
 
@@ -78,5 +130,22 @@ func SetError(code int, params ...interface{}) object.Object {
 	line := params[0].(int)
 	Errp.Error = append(Errp.Error, Error{Message: message, Line: line})
 	log.SetLog(line, "", "ERROR", message)
-	return &object.ERROR{Value: message, Line: params[0].(int)}
+	return &object.ERROR{Value: message, Code: code, Line: params[0].(int)}
+}
+
+//Raise set error made by program(RAISE)
+func Raise(code int, line int, message string) object.Object {
+	err := SetError(900, line, message).(*object.ERROR)
+	err.Code = code
+	return err
+}
+
+//Count Get number of errors
+func Count() int {
+	return len(Errp.Error)
+}
+
+//Recover Remove errors after n(they were caught by try)
+func Recover(n int) {
+	Errp.Error = Errp.Error[:n]
 }
@@ -0,0 +1,114 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"math"
+	"math/big"
+)
+
+//intOverflows check result of int64 calculation doesn't fit in int64
+func intOverflows(lval int64, operator string, rval int64) bool {
+	switch operator {
+	case "+":
+		sum := lval + rval
+		return (lval > 0 && rval > 0 && sum < 0) || (lval < 0 && rval < 0 && sum >= 0)
+	case "-":
+		diff := lval - rval
+		return (lval >= 0 && rval < 0 && diff < 0) || (lval < 0 && rval > 0 && diff >= 0)
+	case "*":
+		if lval == 0 || rval == 0 {
+			return false
+		}
+		if (lval == -1 && rval == math.MinInt64) || (rval == -1 && lval == math.MinInt64) {
+			return true
+		}
+		return (lval*rval)/rval != lval
+	case "/":
+		return lval == math.MinInt64 && rval == -1
+	}
+	return false
+}
+
+//bigToFloat convert big integer to float64
+func bigToFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(v).Float64()
+	return f
+}
+
+//bigCalc calculate big integers(result fits in int64 becomes Int)
+func bigCalc(lval *big.Int, operator string, rval *big.Int, line int) object.Object {
+	expr := lval.String() + " " + operator + " " + rval.String()
+	if (operator == "/" || operator == "%") && rval.Sign() == 0 {
+		return errorwords.SetError(209, line, expr)
+	}
+	var result *big.Int
+	switch operator {
+	case "+":
+		result = new(big.Int).Add(lval, rval)
+	case "-":
+		result = new(big.Int).Sub(lval, rval)
+	case "*":
+		result = new(big.Int).Mul(lval, rval)
+	case "/":
+		quo, rem := new(big.Int).QuoRem(lval, rval, new(big.Int))
+		if rem.Sign() != 0 && Exact {
+			return ratCalc(new(big.Rat).SetInt(lval), operator, new(big.Rat).SetInt(rval), line)
+		}
+		if rem.Sign() != 0 {
+			return floatCalc(bigToFloat(lval), operator, bigToFloat(rval), line)
+		}
+		result = quo
+	case "%":
+		result = new(big.Int).Rem(lval, rval)
+	case "<", ">", "<=", ">=", "!=", "==":
+		return bigCompare(lval.Cmp(rval), operator, expr, line)
+	default:
+		return errorwords.SetError(204, line, operator)
+	}
+	log.SetLog(line, expr, result.String(), "計算(大きな整数)")
+	return object.NewInteger(result, line)
+}
+
+//bigCompare make Bool from result of Cmp
+func bigCompare(cmp int, operator string, expr string, line int) object.Object {
+	var b bool
+	switch operator {
+	case "<":
+		b = cmp < 0
+	case ">":
+		b = cmp > 0
+	case "<=":
+		b = cmp <= 0
+	case ">=":
+		b = cmp >= 0
+	case "!=":
+		b = cmp != 0
+	case "==":
+		b = cmp == 0
+	}
+	log.SetLog(line, expr, booltoString(b), "評価(true or false)")
+	return makeBoolObj(b, line)
+}
+
+//evalBigInfix Infix Expression Evaluator(left is big integer)
+func evalBigInfix(operator string, lval *big.Int, right object.Object, line int) object.Object {
+	switch rval := right.GetVal().(type) {
+	case *big.Int:
+		return bigCalc(lval, operator, rval, line)
+	case int64:
+		return bigCalc(lval, operator, big.NewInt(rval), line)
+	case *big.Rat:
+		return ratCalc(new(big.Rat).SetInt(lval), operator, rval, line)
+	case float64:
+		return floatCalc(bigToFloat(lval), operator, rval, line)
+	case string:
+		if operator != "+" {
+			return errorwords.SetError(207, line)
+		}
+		log.SetLog(line, lval.String()+" + "+rval, lval.String()+rval, "文字列結合")
+		return &object.String{Value: lval.String() + rval, Line: line}
+	}
+	return errorwords.SetError(203, line, "整数", "数,文字列以外")
+}
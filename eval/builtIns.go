@@ -2,14 +2,17 @@ package eval
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"github.com/hmwri/peridot/errorwords"
 	"github.com/hmwri/peridot/log"
 	"github.com/hmwri/peridot/object"
 	"math"
+	"math/big"
 	"math/rand"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -30,8 +33,11 @@ var builtIns = map[string]*object.BuiltIn{
 			case *object.Array:
 				log.SetLog(line, "SIZE("+arg.Inspect()+")", strconv.Itoa(len(arg.Elements)), "組み込み関数SIZEを実行")
 				return &object.Int{Value: int64(len(arg.Elements)), Line: line}
+			case *object.Map:
+				log.SetLog(line, "SIZE("+arg.Inspect()+")", strconv.Itoa(len(arg.Keys)), "組み込み関数SIZEを実行")
+				return &object.Int{Value: int64(len(arg.Keys)), Line: line}
 			default:
-				return errorwords.SetError(301, line, "SIZE", "文字列,配列")
+				return errorwords.SetError(301, line, "SIZE", "文字列,配列,連想配列")
 			}
 		},
 	},
@@ -43,13 +49,13 @@ var builtIns = map[string]*object.BuiltIn{
 			}
 			switch arg := args[0].(type) {
 			case *object.Array:
-				if arg == args[1] {
+				if containsObject(args[1], arg) {
 					return errorwords.SetError(303, line)
 				}
 				before := arg.Inspect()
 				arg.Elements = append(arg.Elements, args[1])
 				log.SetLog(line, "ADD("+before+")", arg.Inspect(), "組み込み関数ADDを実行")
-				return nil
+				return object.NULL
 			default:
 				return errorwords.SetError(302, line)
 			}
@@ -77,7 +83,7 @@ var builtIns = map[string]*object.BuiltIn{
 				before := arg.Inspect()
 				arg.Elements = delete(arg.Elements, num)
 				log.SetLog(line, "ADD("+before+")", arg.Inspect(), "組み込み関数DELETEを実行")
-				return nil
+				return object.NULL
 			default:
 				return errorwords.SetError(302, line)
 			}
@@ -222,6 +228,13 @@ var builtIns = map[string]*object.BuiltIn{
 				}
 				log.SetLog(line, "ROOT("+arg.Inspect()+")", fmt.Sprintf("%v", math.Sqrt(arg.Value)), "組み込み関数ROOTを実行")
 				return &object.Float{Value: math.Sqrt(arg.Value), Line: line}
+			case *object.Rational:
+				if arg.Value.Sign() < 0 {
+					return errorwords.SetError(301, line, "ROOT", "0以上")
+				}
+				root := math.Sqrt(ratToFloat(arg.Value))
+				log.SetLog(line, "ROOT("+arg.Inspect()+")", fmt.Sprintf("%v", root), "組み込み関数ROOTを実行")
+				return &object.Float{Value: root, Line: line}
 			default:
 				return errorwords.SetError(301, line, "ROOT", "数値")
 			}
@@ -250,7 +263,12 @@ var builtIns = map[string]*object.BuiltIn{
 				}
 				val, err := strconv.ParseInt(arg.Value, 10, 64)
 				if err != nil {
-					return errorwords.SetError(111, line, arg.Value)
+					bigval, ok := new(big.Int).SetString(arg.Value, 10)
+					if !ok {
+						return errorwords.SetError(111, line, arg.Value)
+					}
+					log.SetLog(line, `"`+arg.Value+`"`, bigval.String(), "組み込み関数TONUMを実行")
+					return &object.BigInt{Value: bigval, Line: line}
 				}
 				log.SetLog(line, `"`+arg.Value+`"`, fmt.Sprintf("%v", val), "組み込み関数TONUMを実行")
 				return &object.Int{Value: val, Line: line}
@@ -259,34 +277,47 @@ var builtIns = map[string]*object.BuiltIn{
 			}
 		},
 	},
+	//number(fraction etc.) convert to float
+	"TOFLOAT": &object.BuiltIn{
+		Func: func(line int, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return errorwords.SetError(300, line, "TOFLOAT", 1)
+			}
+			var val float64
+			switch arg := args[0].GetVal().(type) {
+			case int64:
+				val = float64(arg)
+			case float64:
+				val = arg
+			case *big.Int:
+				val = bigToFloat(arg)
+			case *big.Rat:
+				val = ratToFloat(arg)
+			default:
+				return errorwords.SetError(301, line, "TOFLOAT", "数値")
+			}
+			log.SetLog(line, "TOFLOAT("+args[0].Inspect()+")", strconv.FormatFloat(val, 'f', -1, 64), "組み込み関数TOFLOATを実行")
+			return &object.Float{Value: val, Line: line}
+		},
+	},
 	//rand(min,max)
 	"RAND": &object.BuiltIn{
 		Func: func(line int, args ...object.Object) object.Object {
 			if len(args) != 2 {
 				return errorwords.SetError(300, line, "RAND", 2)
 			}
-			switch min := args[0].(type) {
-			case *object.Int:
-				switch max := args[1].(type) {
-				case *object.Int:
+			if min, ok := args[0].(*object.Int); ok {
+				if max, ok := args[1].(*object.Int); ok {
 					return &object.Int{Value: randInt(min.Value, max.Value, line), Line: line}
-				case *object.Float:
-					return &object.Float{Value: randFloat(float64(min.Value), max.Value, line), Line: line}
-				default:
-					return errorwords.SetError(301, line, "RAND", "数値")
 				}
-			case *object.Float:
-				switch max := args[1].(type) {
-				case *object.Int:
-					return &object.Float{Value: randFloat(min.Value, float64(max.Value), line), Line: line}
-				case *object.Float:
-					return &object.Float{Value: randFloat(float64(min.Value), max.Value, line), Line: line}
-				default:
-					return errorwords.SetError(301, line, "RAND", "数値")
-				}
-			default:
+			}
+			//other numbers(Float,fraction,big integer) are random Float
+			min, ok := object.ToFloat(args[0])
+			max, ok2 := object.ToFloat(args[1])
+			if !ok || !ok2 {
 				return errorwords.SetError(301, line, "RAND", "数値")
 			}
+			return &object.Float{Value: randFloat(min, max, line), Line: line}
 		},
 	},
 	//Print
@@ -297,11 +328,45 @@ var builtIns = map[string]*object.BuiltIn{
 			}
 			if str, ok := args[0].(*object.String); ok {
 				fmt.Println(str.Value)
-				return nil
+				return object.NULL
 			}
 			log.SetLog(line, args[0].Inspect(), "出力", "組み込み関数SAYを実行")
 			fmt.Println(args[0].Inspect())
-			return nil
+			return object.NULL
+		},
+	},
+	//Print without newline
+	"PRINT": &object.BuiltIn{
+		Func: func(line int, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return errorwords.SetError(300, line, "PRINT", 1)
+			}
+			log.SetLog(line, args[0].Inspect(), "出力", "組み込み関数PRINTを実行")
+			fmt.Print(toText(args[0]))
+			return object.NULL
+		},
+	},
+	//FORMAT("{} is {:.2f}",a,b) make formatted string
+	"FORMAT": &object.BuiltIn{
+		Func: func(line int, args ...object.Object) object.Object {
+			result := formatString("FORMAT", line, args)
+			if isError(result) {
+				return result
+			}
+			log.SetLog(line, "FORMAT("+args[0].Inspect()+")", result.Inspect(), "組み込み関数FORMATを実行")
+			return result
+		},
+	},
+	//Print formatted string
+	"SAYF": &object.BuiltIn{
+		Func: func(line int, args ...object.Object) object.Object {
+			result := formatString("SAYF", line, args)
+			if isError(result) {
+				return result
+			}
+			log.SetLog(line, result.Inspect(), "出力", "組み込み関数SAYFを実行")
+			fmt.Println(result.(*object.String).Value)
+			return object.NULL
 		},
 	},
 	//Wait some time
@@ -317,9 +382,66 @@ var builtIns = map[string]*object.BuiltIn{
 			second := int(s.Value)
 			log.SetLog(line, args[0].Inspect()+"秒", "待つ", "組み込み関数SLEEPを実行")
 			time.Sleep(time.Duration(second) * time.Second)
-			return nil
+			return object.NULL
+		},
+	},
+	//RAISE(message,code) make error
+	"RAISE": &object.BuiltIn{
+		Func: func(line int, args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return errorwords.SetError(300, line, "RAISE", "1または2")
+			}
+			//raise caught error again
+			if ev, ok := args[0].(*object.ErrorValue); ok && len(args) == 1 {
+				log.SetLog(line, ev.Inspect(), "ERROR", "組み込み関数RAISEを実行")
+				return errorwords.Raise(ev.Code, line, ev.Message)
+			}
+			code := int64(900)
+			if len(args) == 2 {
+				c, ok := args[1].(*object.Int)
+				if !ok {
+					return errorwords.SetError(309, line, "RAISE", 2, "整数(エラーコード)")
+				}
+				code = c.Value
+			}
+			log.SetLog(line, args[0].Inspect(), "ERROR", "組み込み関数RAISEを実行")
+			return errorwords.Raise(int(code), line, toText(args[0]))
 		},
 	},
+	//object convert to JSON string
+	"TOJSON": &object.BuiltIn{Func: toJSON},
+	//JSON string convert to object
+	"FROMJSON": &object.BuiltIn{Func: fromJSON},
+	//read CSV file
+	"READCSV": &object.BuiltIn{Func: readCSV},
+	//write rows to CSV file
+	"WRITECSV": &object.BuiltIn{Func: writeCSV},
+	//CSV string convert to rows
+	"PARSECSV": &object.BuiltIn{Func: parseCSV},
+	//rows convert to CSV string
+	"FORMATCSV": &object.BuiltIn{Func: formatCSV},
+	//current date and time
+	"NOW": &object.BuiltIn{Func: now},
+	//today(00:00:00)
+	"TODAY": &object.BuiltIn{Func: today},
+	//time convert to string
+	"FORMATTIME": &object.BuiltIn{Func: formatTime},
+	//string convert to time
+	"PARSETIME": &object.BuiltIn{Func: parseTime},
+	//add days to time
+	"ADDDAYS": &object.BuiltIn{Func: addDays},
+	//difference of two times(seconds)
+	"DIFFSECONDS": &object.BuiltIn{Func: diffSeconds},
+	//weekday name of time
+	"WEEKDAY": &object.BuiltIn{Func: weekday},
+	//regular expression match
+	"MATCH": &object.BuiltIn{Func: match},
+	//find all regular expression matches
+	"FINDALL": &object.BuiltIn{Func: findAll},
+	//replace all regular expression matches
+	"REPLACEALL": &object.BuiltIn{Func: replaceAll},
+	//split string by regular expression
+	"SPLITRE": &object.BuiltIn{Func: splitRe},
 }
 
 //isNumber If a character is number return true
@@ -370,3 +492,168 @@ func randFloat(min float64, max float64, line int) float64 {
 	log.SetLog(line, "乱数("+fmt.Sprintf("%v", min)+`~`+fmt.Sprintf("%v", max)+")", fmt.Sprintf("%v", result), "組み込み関数RANDを実行")
 	return result
 }
+
+//toText object to output text(String is not quoted)
+func toText(obj object.Object) string {
+	if str, ok := obj.(*object.String); ok {
+		return str.Value
+	}
+	return obj.Inspect()
+}
+
+//formatString replace placeholders({},{:.2f},{:>8}...) in args[0] with args[1:]
+func formatString(name string, line int, args []object.Object) object.Object {
+	if len(args) < 1 {
+		return errorwords.SetError(300, line, name, "1以上")
+	}
+	format, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, name, "文字列")
+	}
+	values := args[1:]
+	src := []rune(format.Value)
+	var out bytes.Buffer
+	count := 0
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '{' && i+1 < len(src) && src[i+1] == '{':
+			out.WriteRune('{')
+			i++
+		case src[i] == '}' && i+1 < len(src) && src[i+1] == '}':
+			out.WriteRune('}')
+			i++
+		case src[i] == '{':
+			end := i + 1
+			for end < len(src) && src[end] != '}' {
+				end++
+			}
+			if end == len(src) {
+				return errorwords.SetError(316, line, name, string(src[i:]))
+			}
+			spec := string(src[i+1 : end])
+			if count < len(values) {
+				text, err := formatValue(name, spec, values[count], line)
+				if err != nil {
+					return err
+				}
+				out.WriteString(text)
+			}
+			count++
+			i = end
+		default:
+			out.WriteRune(src[i])
+		}
+	}
+	if count != len(values) {
+		return errorwords.SetError(315, line, name, count, len(values))
+	}
+	return &object.String{Value: out.String(), Line: line}
+}
+
+//formatValue format one value by spec([:][[fill]align][0][width][.precision][type])
+func formatValue(name string, spec string, value object.Object, line int) (string, object.Object) {
+	if spec == "" {
+		return toText(value), nil
+	}
+	if spec[0] != ':' {
+		return "", errorwords.SetError(316, line, name, "{"+spec+"}")
+	}
+	rs := []rune(spec[1:])
+	fill, align := ' ', rune(0)
+	pos := 0
+	if len(rs) >= 2 && strings.ContainsRune("<>^", rs[1]) {
+		fill, align = rs[0], rs[1]
+		pos = 2
+	} else if len(rs) >= 1 && strings.ContainsRune("<>^", rs[0]) {
+		align = rs[0]
+		pos = 1
+	}
+	zero := false
+	if pos < len(rs) && rs[pos] == '0' {
+		zero = true
+		pos++
+	}
+	width := 0
+	for pos < len(rs) && isNumber(rs[pos]) {
+		width = width*10 + int(rs[pos]-'0')
+		pos++
+	}
+	precision := -1
+	if pos < len(rs) && rs[pos] == '.' {
+		pos++
+		if pos >= len(rs) || !isNumber(rs[pos]) {
+			return "", errorwords.SetError(316, line, name, "{"+spec+"}")
+		}
+		precision = 0
+		for pos < len(rs) && isNumber(rs[pos]) {
+			precision = precision*10 + int(rs[pos]-'0')
+			pos++
+		}
+	}
+	verb := rune(0)
+	if pos < len(rs) {
+		verb = rs[pos]
+		pos++
+	}
+	if pos != len(rs) || (verb != 0 && !strings.ContainsRune("fds", verb)) {
+		return "", errorwords.SetError(316, line, name, "{"+spec+"}")
+	}
+	var text string
+	isNumeric := value.Type() == object.IntOBJ || value.Type() == object.FloatOBJ || value.Type() == object.BigIntOBJ || value.Type() == object.RationalOBJ
+	switch {
+	case verb == 'f' || (verb == 0 && precision >= 0):
+		if !isNumeric {
+			return "", errorwords.SetError(317, line, name, "{"+spec+"}", value.Type())
+		}
+		if precision < 0 {
+			precision = 6
+		}
+		f, _ := value.GetVal().(float64)
+		if i, ok := value.GetVal().(int64); ok {
+			f = float64(i)
+		}
+		if b, ok := value.GetVal().(*big.Int); ok {
+			f = bigToFloat(b)
+		}
+		if r, ok := value.GetVal().(*big.Rat); ok {
+			f = ratToFloat(r)
+		}
+		text = strconv.FormatFloat(f, 'f', precision, 64)
+	case verb == 'd':
+		if (value.Type() != object.IntOBJ && value.Type() != object.BigIntOBJ) || precision >= 0 {
+			return "", errorwords.SetError(317, line, name, "{"+spec+"}", value.Type())
+		}
+		text = value.Inspect()
+	default:
+		if verb == 's' && precision >= 0 {
+			return "", errorwords.SetError(317, line, name, "{"+spec+"}", value.Type())
+		}
+		text = toText(value)
+	}
+	pad := width - utf8.RuneCountInString(text)
+	if pad <= 0 {
+		return text, nil
+	}
+	if zero && align == 0 && isNumeric {
+		sign := ""
+		if strings.HasPrefix(text, "-") {
+			sign, text = "-", text[1:]
+		}
+		return sign + strings.Repeat("0", pad) + text, nil
+	}
+	if align == 0 {
+		align = '<'
+		if isNumeric {
+			align = '>'
+		}
+	}
+	switch align {
+	case '>':
+		return strings.Repeat(string(fill), pad) + text, nil
+	case '^':
+		left := pad / 2
+		return strings.Repeat(string(fill), left) + text + strings.Repeat(string(fill), pad-left), nil
+	default:
+		return text + strings.Repeat(string(fill), pad), nil
+	}
+}
@@ -0,0 +1,68 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"strings"
+)
+
+//evalEquality evaluate == and !=(any values can be compared.Different types are not equal except numbers)
+func evalEquality(operator string, left object.Object, right object.Object, line int) object.Object {
+	eq := object.Equal(left, right)
+	if operator == "!=" {
+		eq = !eq
+	}
+	log.SetLog(line, left.Inspect()+" "+operator+" "+right.Inspect(), booltoString(eq), "評価(true or false)")
+	return makeBoolObj(eq, line)
+}
+
+//isSequence value is String or Array(compared in lexicographic order)
+func isSequence(obj object.Object) bool {
+	return obj.Type() == object.StringOBJ || obj.Type() == object.ArrayOBJ
+}
+
+//compareValues compare numbers,strings,arrays(-1,0,1).If they can't be compared,return the values which can't be compared(in arrays,the elements)
+func compareValues(a, b object.Object) (int, object.Object, object.Object) {
+	if cmp, ok := object.CompareNumbers(a, b); ok {
+		return cmp, nil, nil
+	}
+	switch a := a.(type) {
+	case *object.String:
+		if b, ok := b.(*object.String); ok {
+			return strings.Compare(a.Value, b.Value), nil, nil
+		}
+	case *object.Array:
+		b, ok := b.(*object.Array)
+		if !ok {
+			break
+		}
+		//first different element decides order,else shorter array is smaller
+		for i := 0; i < len(a.Elements) && i < len(b.Elements); i++ {
+			cmp, badLeft, badRight := compareValues(a.Elements[i], b.Elements[i])
+			if badLeft != nil {
+				return 0, badLeft, badRight
+			}
+			if cmp != 0 {
+				return cmp, nil, nil
+			}
+		}
+		switch {
+		case len(a.Elements) < len(b.Elements):
+			return -1, nil, nil
+		case len(a.Elements) > len(b.Elements):
+			return 1, nil, nil
+		}
+		return 0, nil, nil
+	}
+	return 0, a, b
+}
+
+//evalOrder evaluate <,>,<=,>= of strings and arrays
+func evalOrder(operator string, left object.Object, right object.Object, line int) object.Object {
+	cmp, badLeft, badRight := compareValues(left, right)
+	if badLeft != nil {
+		return errorwords.SetError(215, line, badLeft.Type(), badRight.Type())
+	}
+	return bigCompare(cmp, operator, left.Inspect()+" "+operator+" "+right.Inspect(), line)
+}
@@ -0,0 +1,204 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//readCSV READCSV(path,header) read CSV file and return array of rows
+func readCSV(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "READCSV", "1または2")
+	}
+	path, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, "READCSV", "文字列(ファイル名)")
+	}
+	header, err := csvHeaderArg("READCSV", args, line)
+	if err != nil {
+		return err
+	}
+	f, ferr := os.Open(path.Value)
+	if ferr != nil {
+		return errorwords.SetError(313, line, "READCSV", path.Value, "読み込み")
+	}
+	defer f.Close()
+	result := parseCSVReader("READCSV", f, header, line)
+	if isError(result) {
+		return result
+	}
+	log.SetLog(line, "READCSV("+path.Inspect()+")", result.Inspect(), "組み込み関数READCSVを実行")
+	return result
+}
+
+//parseCSV PARSECSV(string,header) parse CSV string and return array of rows
+func parseCSV(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "PARSECSV", "1または2")
+	}
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, "PARSECSV", "文字列")
+	}
+	header, err := csvHeaderArg("PARSECSV", args, line)
+	if err != nil {
+		return err
+	}
+	result := parseCSVReader("PARSECSV", strings.NewReader(str.Value), header, line)
+	if isError(result) {
+		return result
+	}
+	log.SetLog(line, "PARSECSV("+str.Inspect()+")", result.Inspect(), "組み込み関数PARSECSVを実行")
+	return result
+}
+
+//writeCSV WRITECSV(path,rows) write rows to CSV file
+func writeCSV(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "WRITECSV", 2)
+	}
+	path, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, "WRITECSV", "文字列(ファイル名)")
+	}
+	out, err := formatCSVRows("WRITECSV", 2, args[1], line)
+	if err != nil {
+		return err
+	}
+	if ferr := os.WriteFile(path.Value, []byte(out), 0644); ferr != nil {
+		return errorwords.SetError(313, line, "WRITECSV", path.Value, "書き込み")
+	}
+	log.SetLog(line, "WRITECSV("+path.Inspect()+")", args[1].Inspect(), "組み込み関数WRITECSVを実行")
+	return object.NULL
+}
+
+//formatCSV FORMATCSV(rows) convert rows to CSV string
+func formatCSV(line int, args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return errorwords.SetError(300, line, "FORMATCSV", 1)
+	}
+	out, err := formatCSVRows("FORMATCSV", 1, args[0], line)
+	if err != nil {
+		return err
+	}
+	log.SetLog(line, "FORMATCSV("+args[0].Inspect()+")", `"`+out+`"`, "組み込み関数FORMATCSVを実行")
+	return &object.String{Value: out, Line: line}
+}
+
+//csvHeaderArg get second argument(use header or not)
+func csvHeaderArg(name string, args []object.Object, line int) (bool, object.Object) {
+	if len(args) != 2 {
+		return false, nil
+	}
+	b, ok := args[1].(*object.Bool)
+	if !ok {
+		return false, errorwords.SetError(309, line, name, 2, "真偽値")
+	}
+	return b.Value, nil
+}
+
+//parseCSVReader read all records.If header is true,each row becomes Map keyed by first record
+func parseCSVReader(name string, r io.Reader, header bool, line int) object.Object {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		if pe, ok := err.(*csv.ParseError); ok {
+			return errorwords.SetError(312, line, name, pe.Line, pe.Err.Error())
+		}
+		return errorwords.SetError(312, line, name, 1, err.Error())
+	}
+	rows := &object.Array{Elements: []object.Object{}, Line: line}
+	if header && len(records) > 0 {
+		keys := records[0]
+		for _, record := range records[1:] {
+			m := object.NewMap(line)
+			for i, key := range keys {
+				m.Set(key, csvCell(record[i], line))
+			}
+			rows.Elements = append(rows.Elements, m)
+		}
+		return rows
+	}
+	for _, record := range records {
+		row := &object.Array{Elements: []object.Object{}, Line: line}
+		for _, cell := range record {
+			row.Elements = append(row.Elements, csvCell(cell, line))
+		}
+		rows.Elements = append(rows.Elements, row)
+	}
+	return rows
+}
+
+//csvCell convert cell to Int or Float if it is number,else String
+func csvCell(cell string, line int) object.Object {
+	digits := strings.TrimPrefix(cell, "-")
+	switch isNum([]rune(digits)) {
+	case "INT":
+		if val, err := strconv.ParseInt(cell, 10, 64); err == nil {
+			return &object.Int{Value: val, Line: line}
+		}
+		if val, ok := new(big.Int).SetString(cell, 10); ok {
+			return &object.BigInt{Value: val, Line: line}
+		}
+	case "FLOAT":
+		if val, err := strconv.ParseFloat(cell, 64); err == nil {
+			return &object.Float{Value: val, Line: line}
+		}
+	}
+	return &object.String{Value: cell, Line: line}
+}
+
+//formatCSVRows convert array of rows(Array or Map) to CSV text.Map rows write header first
+func formatCSVRows(name string, pos int, obj object.Object, line int) (string, object.Object) {
+	rows, ok := obj.(*object.Array)
+	if !ok {
+		return "", errorwords.SetError(309, line, name, pos, "配列")
+	}
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	var keys []string
+	for _, row := range rows.Elements {
+		switch row := row.(type) {
+		case *object.Array:
+			record := []string{}
+			for _, cell := range row.Elements {
+				record = append(record, csvString(cell))
+			}
+			w.Write(record)
+		case *object.Map:
+			if keys == nil {
+				keys = row.Keys
+				w.Write(keys)
+			}
+			record := []string{}
+			for _, key := range keys {
+				cell, found := row.Get(key)
+				if !found {
+					record = append(record, "")
+					continue
+				}
+				record = append(record, csvString(cell))
+			}
+			w.Write(record)
+		default:
+			return "", errorwords.SetError(314, line, name)
+		}
+	}
+	w.Flush()
+	return out.String(), nil
+}
+
+//csvString cell object to string(String is not quoted)
+func csvString(obj object.Object) string {
+	if str, ok := obj.(*object.String); ok {
+		return str.Value
+	}
+	return obj.Inspect()
+}
@@ -6,12 +6,26 @@ import (
 	"github.com/hmwri/peridot/errorwords"
 	"github.com/hmwri/peridot/log"
 	"github.com/hmwri/peridot/object"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	"golang.org/x/exp/utf8string"
 )
 
+//LegacyScope If true,if,loop,match,try bodies don't make new env(old behavior:make in block is visible outside)
+var LegacyScope = false
+
+//blockEnv return env for if,loop,match,try body(each loop iteration gets new one)
+func blockEnv(env *object.Env) *object.Env {
+	if LegacyScope {
+		return env
+	}
+	return object.AddEnv(env)
+}
+
 //Eval evaluator
 func Eval(node ast.Node, env *object.Env) object.Object {
 
@@ -21,11 +35,16 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 	case *ast.ExpressionStatement:
 		return Eval(node.Expression, env)
 	case *ast.Int:
+		if node.Big != nil {
+			return &object.BigInt{Value: node.Big, Line: node.Token.Line}
+		}
 		return &object.Int{Value: node.Value, Line: node.Token.Line}
 	case *ast.Float:
 		return &object.Float{Value: node.Value, Line: node.Token.Line}
 	case *ast.Bool:
 		return &object.Bool{Value: node.Value, Line: node.Token.Line}
+	case *ast.Nil:
+		return object.NULL
 	case *ast.String:
 		return &object.String{Value: node.Value, Line: node.Token.Line}
 	case *ast.Array:
@@ -65,17 +84,48 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 		return evalStmt(node.Statements, env)
 	case *ast.If:
 		return evalIf(node, env, node.Token.Line)
+	case *ast.Match:
+		return evalMatch(node, env, node.Token.Line)
+	case *ast.Try:
+		return evalTry(node, env, node.Token.Line)
 	case *ast.Loop:
 		return evalLoop(node, env, node.Token.Line)
+	case *ast.ForEach:
+		return evalForEach(node, env, node.Token.Line)
+	case *ast.Range:
+		start, end, err := evalRangeBounds(node, env)
+		if err != nil {
+			return err
+		}
+		array := &object.Array{Elements: []object.Object{}, Line: node.Token.Line}
+		next := rangeIter(start, end, node.Token.Line)
+		for _, value, ok := next(); ok; _, value, ok = next() {
+			array.Elements = append(array.Elements, value)
+		}
+		return array
 	case *ast.Return:
+		if tc := evalTailCall(node, env); tc != nil {
+			return tc
+		}
 		val := Eval(node.Value, env)
 		if isError(val) {
 			return val
 		}
 		return &object.ReturnValue{Value: val, Line: node.Token.Line}
 	case *ast.Stop:
+		if node.Label != nil {
+			log.SetLog(node.Token.Line, "stop "+node.Label.Value, "stop", "ループ離脱(loop "+node.Label.Value+")")
+			return &object.Stop{Label: node.Label.Value, Line: node.Token.Line}
+		}
 		log.SetLog(node.Token.Line, "stop", "stop", "ループ離脱")
 		return &object.Stop{Line: node.Token.Line}
+	case *ast.Next:
+		if node.Label != nil {
+			log.SetLog(node.Token.Line, "next "+node.Label.Value, "next", "次の繰り返しへ(loop "+node.Label.Value+")")
+			return &object.Next{Label: node.Label.Value, Line: node.Token.Line}
+		}
+		log.SetLog(node.Token.Line, "next", "next", "次の繰り返しへ")
+		return &object.Next{Line: node.Token.Line}
 	case *ast.Make:
 		if node == nil {
 			return errorwords.SetError(200, 0)
@@ -90,37 +140,83 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 				return errorwords.SetError(230, node.Token.Line, v.Name.String())
 			}
 		}
-		if val == nil {
-			return errorwords.SetError(211, node.Token.Line)
+		if err := checkDefinable(node.Name.Value, node.Token.Line, env); err != nil {
+			return err
 		}
 		log.SetLog(node.Token.Line, node.Name.String(), val.Inspect(), "変数("+node.Name.String()+")を定義し("+val.Inspect()+")を代入")
 		env.SetEnv(node.Name.Value, val)
+	case *ast.Const:
+		val := Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if v, ok := node.Value.(*ast.Function); ok && v.Name != nil {
+			return errorwords.SetError(230, node.Token.Line, v.Name.String())
+		}
+		if err := checkDefinable(node.Name.Value, node.Token.Line, env); err != nil {
+			return err
+		}
+		log.SetLog(node.Token.Line, node.Name.String(), val.Inspect(), "定数("+node.Name.String()+")を定義し("+val.Inspect()+")を代入")
+		env.SetConst(node.Name.Value, val)
 	case *ast.Assign:
 		val := Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if node.Operator != "" {
+			//x += 1 is evaluated as x = x + 1
+			current := evalIdent(node.Name, node.Token.Line, env)
+			if isError(current) {
+				return current
+			}
+			val = evalInfix(node.Operator, current, val, node.Token.Line)
+			if isError(val) {
+				return val
+			}
+		}
 		if v, ok := node.Value.(*ast.Function); ok {
 			//If you try to assign an anonymous function
 			if v.Name != nil {
 				return errorwords.SetError(230, node.Token.Line, v.Name.String())
 			}
 		}
-		if val == nil {
-			return errorwords.SetError(211, node.Token.Line)
+		if env.IsConst(node.Name.Value) {
+			return errorwords.SetError(212, node.Token.Line, node.Name.Value)
 		}
-		if _, found := env.GetEnv(node.Name.Value); found {
-			env.SetEnv(node.Name.Value, val)
+		if env.Assign(node.Name.Value, val) {
 			log.SetLog(node.Token.Line, node.Name.String(), val.Inspect(), "変数("+node.Name.String()+")に"+val.Inspect()+"を代入")
 		} else {
 			return errorwords.SetError(210, node.Token.Line, node.Name.Value)
 		}
 
+	case *ast.IndexAssign:
+		return evalIndexAssign(node, env, node.Token.Line)
+	case *ast.TypeDecl:
+		return evalTypeDecl(node, env, node.Token.Line)
+	case *ast.Import:
+		return evalImport(node, env, node.Token.Line)
+	case *ast.Field:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		return evalField(left, node.Name.Value, node.Token.Line)
+	case *ast.FieldAssign:
+		return evalFieldAssign(node, env, node.Token.Line)
 	case *ast.Identifier:
 		return evalIdent(node, node.Token.Line, env)
 	case *ast.Function:
 		params := node.Parameters
 		process := node.Process
 		name := node.Name
-		obj := &object.Function{Params: params, Env: env, Process: process, Name: name, Line: node.Token.Line}
+		obj := &object.Function{Params: params, Defaults: node.Defaults, Rest: node.Rest, Receiver: node.Receiver, RecvType: node.RecvType, Env: env, Process: process, Name: name, Line: node.Token.Line}
+		if node.Receiver != nil {
+			return evalMethodDecl(obj, env, node.Token.Line)
+		}
 		if name != nil {
+			if err := checkDefinable(name.Value, node.Token.Line, env); err != nil {
+				return err
+			}
 			log.SetLog(node.Token.Line, node.Name.String(), obj.Inspect(), "関数を定義(名前:"+node.Name.String()+")")
 			env.SetEnv(node.Name.Value, obj)
 		} else {
@@ -136,17 +232,27 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 		if len(args) == 1 && args[0].Type() == object.ErrorOBJ {
 			return args[0]
 		}
-		return exeFunction(function, args, node.Token.Line)
+		named, err := evalNamedArgs(node.Named, env)
+		if err != nil {
+			return err
+		}
+		return exeFunction(function, args, named, node.Token.Line)
 	default:
 		return errorwords.SetError(200, 0)
 
 	}
-	return nil
+	return object.NULL
 }
 
 //Root Statement Evaluator
-func evalRoot(stmts []ast.Statement, env *object.Env) object.Object {
-	var result object.Object
+func evalRoot(stmts []ast.Statement, env *object.Env) (result object.Object) {
+	//Unexpected panic becomes error instead of crash
+	defer func() {
+		if r := recover(); r != nil {
+			result = errorwords.SetError(299, lastLine(), r)
+		}
+	}()
+	result = object.NULL
 	for _, stmt := range stmts {
 		result = Eval(stmt, env)
 		switch v := result.(type) {
@@ -159,12 +265,20 @@ func evalRoot(stmts []ast.Statement, env *object.Env) object.Object {
 	return result
 }
 
+//lastLine Get line evaluated last(for error without line)
+func lastLine() int {
+	if len(log.Logs) == 0 {
+		return 0
+	}
+	return log.Logs[len(log.Logs)-1].Line
+}
+
 //Block Statement Evaluator
 func evalStmt(stmts []ast.Statement, env *object.Env) object.Object {
-	var result object.Object
+	var result object.Object = object.NULL
 	for _, stmt := range stmts {
 		result = Eval(stmt, env)
-		if result != nil && (result.Type() == object.ReturnOBJ || result.Type() == object.StopOBJ || result.Type() == object.ErrorOBJ) {
+		if result.Type() == object.ReturnOBJ || result.Type() == object.StopOBJ || result.Type() == object.NextOBJ || result.Type() == object.ErrorOBJ {
 			return result
 		}
 
@@ -186,6 +300,22 @@ func evalExps(exps []ast.Expression, env *object.Env) []object.Object {
 	return result
 }
 
+//evalNamedArgs evaluate named arguments(name = value) to Map(keeps order)
+func evalNamedArgs(named []*ast.NamedArg, env *object.Env) (*object.Map, object.Object) {
+	if len(named) == 0 {
+		return nil, nil
+	}
+	result := object.NewMap(named[0].Token.Line)
+	for _, na := range named {
+		evaled := Eval(na.Value, env)
+		if isError(evaled) {
+			return nil, evaled
+		}
+		result.Set(na.Name.Value, evaled)
+	}
+	return result, nil
+}
+
 //Prefix Expression Evaluator
 func evalPrefix(operator string, value object.Object, line int) object.Object {
 	switch operator {
@@ -208,6 +338,10 @@ func evalBang(value object.Object, line int) object.Object {
 	case false:
 		log.SetLog(line, "false", "true", "")
 		return makeBoolObj(true, line)
+	case nil:
+		//!nil is true
+		log.SetLog(line, "nil", "true", "")
+		return makeBoolObj(true, line)
 	default:
 		log.SetLog(line, "Not Boolean(真偽値以外)", "false", "")
 		return makeBoolObj(false, line)
@@ -216,13 +350,23 @@ func evalBang(value object.Object, line int) object.Object {
 
 //Minus Expression Evaluator
 func evalMinus(value object.Object, line int) object.Object {
-	if value.Type() != object.IntOBJ && value.Type() != object.FloatOBJ {
+	if value.Type() != object.IntOBJ && value.Type() != object.FloatOBJ && value.Type() != object.BigIntOBJ && value.Type() != object.RationalOBJ {
 		return errorwords.SetError(202, line)
 	}
 	intval, ok := value.GetVal().(int64)
 	if ok {
+		//-(-9223372036854775808) doesn't fit in int64
+		if intval == math.MinInt64 {
+			return object.NewInteger(new(big.Int).Neg(big.NewInt(intval)), line)
+		}
 		return &object.Int{Value: -intval, Line: line}
 	}
+	if bigval, ok := value.GetVal().(*big.Int); ok {
+		return object.NewInteger(new(big.Int).Neg(bigval), line)
+	}
+	if ratval, ok := value.GetVal().(*big.Rat); ok {
+		return object.NewRational(new(big.Rat).Neg(ratval), line)
+	}
 	floatval, ok := value.GetVal().(float64)
 	if ok {
 		return &object.Float{Value: -floatval, Line: line}
@@ -232,11 +376,29 @@ func evalMinus(value object.Object, line int) object.Object {
 
 //Infix Expression Evaluator
 func evalInfix(operator string, left object.Object, right object.Object, line int) object.Object {
+	switch operator {
+	case "==", "!=":
+		return evalEquality(operator, left, right, line)
+	case "<", ">", "<=", ">=":
+		if isSequence(left) || isSequence(right) {
+			return evalOrder(operator, left, right, line)
+		}
+	}
+	//nil can be used only with == and !=
+	if left == object.NULL || right == object.NULL {
+		return errorwords.SetError(214, line, operator)
+	}
 	switch v := left.GetVal().(type) {
 	case int64:
 		lval := v
 		rval, ok := right.GetVal().(int64)
 		if !ok {
+			if rbig, ok := right.GetVal().(*big.Int); ok {
+				return bigCalc(big.NewInt(lval), operator, rbig, line)
+			}
+			if rrat, ok := right.GetVal().(*big.Rat); ok {
+				return ratCalc(new(big.Rat).SetInt64(lval), operator, rrat, line)
+			}
 			rstr, ok := right.GetVal().(string)
 			if ok {
 				//if right is string ,left value convert to string
@@ -255,6 +417,13 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 			floatLval := float64(lval)
 			return floatCalc(floatLval, operator, floatRval, line)
 		}
+		if (operator == "/" || operator == "%") && rval == 0 {
+			return errorwords.SetError(209, line, infixIntString(lval, " "+operator+" ", rval))
+		}
+		//If result overflows int64,calculate as big integer
+		if intOverflows(lval, operator, rval) {
+			return bigCalc(big.NewInt(lval), operator, big.NewInt(rval), line)
+		}
 		switch operator {
 		case "+":
 			log.SetLog(line, infixIntString(lval, " + ", rval), strconv.FormatInt(lval+rval, 10), "計算")
@@ -266,6 +435,10 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 			log.SetLog(line, infixIntString(lval, " * ", rval), strconv.FormatInt(lval*rval, 10), "計算")
 			return &object.Int{Value: lval * rval, Line: line}
 		case "/":
+			//Exact mode:7 / 2 is fraction 7/2
+			if lval%rval != 0 && Exact {
+				return ratCalc(new(big.Rat).SetInt64(lval), operator, new(big.Rat).SetInt64(rval), line)
+			}
 			if lval%rval != 0 {
 				result := float64(lval) / float64(rval)
 				log.SetLog(line, infixIntString(lval, " / ", rval), fmt.Sprintf("%v", result), "計算")
@@ -312,6 +485,12 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 				log.SetLog(line, lstr+" + "+rstr, lstr+rstr, "文字列結合")
 				return &object.String{Value: lstr + rstr, Line: line}
 			}
+			if rbig, ok := right.GetVal().(*big.Int); ok {
+				return floatCalc(lval, operator, bigToFloat(rbig), line)
+			}
+			if rrat, ok := right.GetVal().(*big.Rat); ok {
+				return floatCalc(lval, operator, ratToFloat(rrat), line)
+			}
 			rval, ok := right.GetVal().(int64)
 			if !ok {
 				return errorwords.SetError(203, line, "整数", "数,文字列以外")
@@ -322,7 +501,10 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 		}
 
 		return floatCalc(lval, operator, rval, line)
-
+	case *big.Int:
+		return evalBigInfix(operator, v, right, line)
+	case *big.Rat:
+		return evalRatInfix(operator, v, right, line)
 	case bool:
 		lval := v
 		rval, ok := right.GetVal().(bool)
@@ -330,12 +512,6 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 			return errorwords.SetError(203, line, "真偽値or式", "真偽値or式以外")
 		}
 		switch operator {
-		case "!=":
-			log.SetLog(line, booltoString(lval)+" != "+booltoString(rval), booltoString(lval != rval), "比較")
-			return makeBoolObj(lval != rval, line)
-		case "==":
-			log.SetLog(line, booltoString(lval)+" == "+booltoString(rval), booltoString(lval == rval), "比較")
-			return makeBoolObj(lval == rval, line)
 		case "and":
 			log.SetLog(line, booltoString(lval)+" and "+booltoString(rval), booltoString(lval && rval), "論理積")
 			return makeBoolObj(lval && rval, line)
@@ -357,7 +533,15 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 			if ok2 {
 				rstr = strconv.FormatFloat(frval, 'f', -1, 64)
 			}
-			if !ok && !ok2 {
+			brval, ok3 := right.GetVal().(*big.Int)
+			if ok3 {
+				rstr = brval.String()
+			}
+			rrval, ok4 := right.GetVal().(*big.Rat)
+			if ok4 {
+				rstr = rrval.RatString()
+			}
+			if !ok && !ok2 && !ok3 && !ok4 {
 				return errorwords.SetError(203, line, "文字列", "文字列,数以外")
 			}
 
@@ -366,14 +550,6 @@ func evalInfix(operator string, left object.Object, right object.Object, line in
 			log.SetLog(line, lstr+" + "+rstr, lstr+rstr, "文字列結合")
 			return &object.String{Value: lstr + rstr, Line: line}
 		}
-		if operator == "==" {
-			log.SetLog(line, lstr+" == "+rstr, booltoString(lstr == rstr), "文字列比較")
-			return makeBoolObj(lstr == rstr, line)
-		}
-		if operator == "!=" {
-			log.SetLog(line, lstr+" != "+rstr, booltoString(lstr != rstr), "文字列比較")
-			return makeBoolObj(lstr != rstr, line)
-		}
 		return errorwords.SetError(207, line)
 
 	default:
@@ -389,23 +565,128 @@ func makeBoolObj(b bool, line int) object.Object {
 
 //evaluate "If Expression"
 func evalIf(i *ast.If, env *object.Env, line int) object.Object {
+	return evalIfChain(i, env, line, 0)
+}
+
+//evaluate If of "else if" chain(depth is number of else if)
+func evalIfChain(i *ast.If, env *object.Env, line int, depth int) object.Object {
 	condition := Eval(i.Condition, env)
 	if isError(condition) {
 		return condition
 	}
-	if condition == nil {
+	if condition == object.NULL {
 		return errorwords.SetError(220, line, "if")
 	}
 	if isTrue(condition) {
+		if depth > 0 {
+			log.SetLog(line, i.Condition.String(), "true", fmt.Sprintf("条件がtrueであったためelse if(%v番目)内を実行", depth))
+			return Eval(i.Consequence, blockEnv(env))
+		}
 		log.SetLog(line, i.Condition.String(), "true", "条件がtrueであったためif内を実行")
-		return Eval(i.Consequence, env)
+		return Eval(i.Consequence, blockEnv(env))
+	} else if i.ElseIf != nil {
+		log.SetLog(line, i.Condition.String(), "false", fmt.Sprintf("条件がfalseであったためelse if(%v番目)の条件を評価", depth+1))
+		return evalIfChain(i.ElseIf, env, i.ElseIf.Token.Line, depth+1)
 	} else if i.Alternative != nil {
 		log.SetLog(line, i.Condition.String(), "false", "条件がfalseであったためelse内を実行")
-		return Eval(i.Alternative, env)
+		return Eval(i.Alternative, blockEnv(env))
 	} else {
 		log.SetLog(line, i.Condition.String(), "false", "条件がfalseであったためif内をスキップ")
-		return nil
+		return object.NULL
+	}
+}
+
+//evaluate "Try Expression".If error occurs in try,evaluate catch
+func evalTry(t *ast.Try, env *object.Env, line int) object.Object {
+	mark := errorwords.Count()
+	result := Eval(t.Block, blockEnv(env))
+	err, ok := result.(*object.ERROR)
+	if !ok {
+		return result
+	}
+	//caught error doesn't make the program fail
+	errorwords.Recover(mark)
+	log.SetLog(line, err.Inspect(), "catch", "エラーが発生したためcatch内を実行")
+	catchEnv := blockEnv(env)
+	if t.Name != nil {
+		//error variable is only in catch(even in legacy scope)
+		if catchEnv == env {
+			catchEnv = object.AddEnv(env)
+		}
+		catchEnv.SetEnv(t.Name.Value, &object.ErrorValue{Code: err.Code, Message: errorMessage(err), Line: err.Line})
 	}
+	return Eval(t.Handler, catchEnv)
+}
+
+//error message without "[n行目]"
+func errorMessage(err *object.ERROR) string {
+	if strings.HasPrefix(err.Value, "[") {
+		if end := strings.Index(err.Value, "]"); end != -1 {
+			return err.Value[end+1:]
+		}
+	}
+	return err.Value
+}
+
+//evaluate "Match Expression"(first matched arm is evaluated)
+func evalMatch(mt *ast.Match, env *object.Env, line int) object.Object {
+	value := Eval(mt.Value, env)
+	if isError(value) {
+		return value
+	}
+	for n, arm := range mt.Arms {
+		matched := false
+		for _, pattern := range arm.Patterns {
+			ok, err := matchPattern(pattern, value, env)
+			if err != nil {
+				return err
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if matched && arm.Guard != nil {
+			guard := Eval(arm.Guard, env)
+			if isError(guard) {
+				return guard
+			}
+			matched = isTrue(guard)
+		}
+		if matched {
+			log.SetLog(arm.Token.Line, value.Inspect(), arm.String(), fmt.Sprintf("matchの%v番目の候補に一致したため実行", n+1))
+			return Eval(arm.Body, blockEnv(env))
+		}
+	}
+	return errorwords.SetError(240, line, value.Inspect())
+}
+
+//check value matches pattern(_ is any value,start..end is range)
+func matchPattern(pattern ast.Expression, value object.Object, env *object.Env) (bool, object.Object) {
+	if ident, ok := pattern.(*ast.Identifier); ok && ident.Value == "_" {
+		return true, nil
+	}
+	if rg, ok := pattern.(*ast.Range); ok {
+		start, end, err := evalRangeBounds(rg, env)
+		if err != nil {
+			return false, err
+		}
+		if start > end {
+			start, end = end, start
+		}
+		switch v := value.GetVal().(type) {
+		case int64:
+			return start <= v && v <= end, nil
+		case float64:
+			return float64(start) <= v && v <= float64(end), nil
+		}
+		return false, nil
+	}
+	p := Eval(pattern, env)
+	if isError(p) {
+		return false, p
+	}
+	return object.Equal(value, p), nil
 }
 
 //evaluate "Loop Expression"
@@ -419,14 +700,15 @@ func evalLoop(l *ast.Loop, env *object.Env, line int) object.Object {
 	if isStr {
 		return errorwords.SetError(500, line, "文字列")
 	}
-	var obj object.Object
+	var obj object.Object = object.NULL
 	fnum, ok := condition.(*object.Float)
 	//loop(number-float){}
 	if ok {
 		for i := 0.0; i < float64(fnum.Value); i++ {
 			log.SetLog(line, fmt.Sprintf("%v <= %v", i+1, fnum.Value), "true", fmt.Sprintf("条件がtrueであったためloop内を実行(%v回目)", i+1))
-			obj = Eval(l.Process, env)
-			if isStopType(obj) {
+			end, result := loopControl(Eval(l.Process, blockEnv(env)), l.Label)
+			obj = result
+			if end {
 				break
 			}
 		}
@@ -438,8 +720,9 @@ func evalLoop(l *ast.Loop, env *object.Env, line int) object.Object {
 	if ok {
 		for i := 0; i < int(num.Value); i++ {
 			log.SetLog(line, fmt.Sprintf("%v <= %v", i+1, num.Value), "true", fmt.Sprintf("条件がtrueであったためloop内を実行(%v回目)", i+1))
-			obj = Eval(l.Process, env)
-			if isStopType(obj) {
+			end, result := loopControl(Eval(l.Process, blockEnv(env)), l.Label)
+			obj = result
+			if end {
 				break
 			}
 		}
@@ -447,18 +730,68 @@ func evalLoop(l *ast.Loop, env *object.Env, line int) object.Object {
 		return obj
 	}
 
-	i := 0
-	for ; isTrue(condition); condition = Eval(l.Condition, env) {
+	for i := 1; ; i++ {
 		if isError(condition) {
 			return condition
 		}
-		if condition == nil {
+		if condition == object.NULL {
 			return errorwords.SetError(220, line, "loop")
 		}
-		i++
+		if !isTrue(condition) {
+			break
+		}
 		log.SetLog(line, l.Condition.String(), "true", fmt.Sprintf("条件がtrueであったためloop内を実行(%v回目)", i))
-		obj := Eval(l.Process, env)
-		if isStopType(obj) {
+		end, result := loopControl(Eval(l.Process, blockEnv(env)), l.Label)
+		obj = result
+		if end {
+			break
+		}
+		condition = Eval(l.Condition, env)
+	}
+	log.SetLog(line, "loop", "end", "ループ終了")
+	return obj
+}
+
+//evaluate "loop x in array" (each iteration has new env for loop variables)
+func evalForEach(fe *ast.ForEach, env *object.Env, line int) object.Object {
+	log.SetLog(line, "loop", "start", "ループ開始")
+	var next iterator
+	if rg, ok := fe.Iterable.(*ast.Range); ok {
+		start, end, err := evalRangeBounds(rg, env)
+		if err != nil {
+			return err
+		}
+		next = rangeIter(start, end, line)
+	} else {
+		iterable := Eval(fe.Iterable, env)
+		if isError(iterable) {
+			return iterable
+		}
+		if iterable == object.NULL {
+			return errorwords.SetError(220, line, "loop")
+		}
+		next = valueIter(iterable, line)
+		if next == nil {
+			return errorwords.SetError(501, line, iterable.Type())
+		}
+	}
+	var obj object.Object = object.NULL
+	for i := 0; ; i++ {
+		key, value, ok := next()
+		if !ok {
+			break
+		}
+		iterEnv := object.AddEnv(env)
+		bind := fe.Value.Value + " = " + value.Inspect()
+		if fe.Index != nil {
+			iterEnv.SetEnv(fe.Index.Value, key)
+			bind = fe.Index.Value + " = " + key.Inspect() + ", " + bind
+		}
+		iterEnv.SetEnv(fe.Value.Value, value)
+		log.SetLog(line, bind, "true", fmt.Sprintf("値を取り出してloop内を実行(%v回目)", i+1))
+		end, result := loopControl(Eval(fe.Process, iterEnv), fe.Label)
+		obj = result
+		if end {
 			break
 		}
 	}
@@ -466,6 +799,93 @@ func evalLoop(l *ast.Loop, env *object.Env, line int) object.Object {
 	return obj
 }
 
+//evaluate start and end of range(they must be Int)
+func evalRangeBounds(rg *ast.Range, env *object.Env) (int64, int64, object.Object) {
+	start := Eval(rg.Start, env)
+	if isError(start) {
+		return 0, 0, start
+	}
+	end := Eval(rg.End, env)
+	if isError(end) {
+		return 0, 0, end
+	}
+	s, ok := start.(*object.Int)
+	e, ok2 := end.(*object.Int)
+	if !ok || !ok2 {
+		return 0, 0, errorwords.SetError(504, rg.Token.Line)
+	}
+	log.SetLog(rg.Token.Line, rg.String(), fmt.Sprintf("%v..%v", s.Value, e.Value), "範囲を評価")
+	return s.Value, e.Value, nil
+}
+
+//iterator Get next key and value of loop(ok is false after the last)
+type iterator func() (key object.Object, value object.Object, ok bool)
+
+//rangeIter make iterator of range.Values are made one by one and it stops at end(no overflow at the end of int64)
+func rangeIter(start, end int64, line int) iterator {
+	step := rangeStep(start, end)
+	i, n, done := start, int64(0), false
+	return func() (object.Object, object.Object, bool) {
+		if done {
+			return nil, nil, false
+		}
+		key, value := &object.Int{Value: n, Line: line}, &object.Int{Value: i, Line: line}
+		if i == end {
+			done = true
+		} else {
+			i, n = i+step, n+1
+		}
+		return key, value, true
+	}
+}
+
+//valueIter make iterator of Array,String,Map(nil if value can't be iterated).Elements at the start are iterated
+func valueIter(iterable object.Object, line int) iterator {
+	n := 0
+	switch it := iterable.(type) {
+	case *object.Array:
+		elements := it.Elements
+		return func() (object.Object, object.Object, bool) {
+			if n >= len(elements) {
+				return nil, nil, false
+			}
+			n++
+			return &object.Int{Value: int64(n - 1), Line: line}, elements[n-1], true
+		}
+	case *object.String:
+		chars := []rune(it.Value)
+		return func() (object.Object, object.Object, bool) {
+			if n >= len(chars) {
+				return nil, nil, false
+			}
+			n++
+			return &object.Int{Value: int64(n), Line: line}, &object.String{Value: string(chars[n-1]), Line: line}, true
+		}
+	case *object.Map:
+		keys := it.Keys[:len(it.Keys):len(it.Keys)]
+		values := make([]object.Object, len(keys))
+		for i, k := range keys {
+			values[i] = it.Pairs[k]
+		}
+		return func() (object.Object, object.Object, bool) {
+			if n >= len(keys) {
+				return nil, nil, false
+			}
+			n++
+			return &object.String{Value: keys[n-1], Line: line}, values[n-1], true
+		}
+	}
+	return nil
+}
+
+//range step(1 or -1)
+func rangeStep(start, end int64) int64 {
+	if start > end {
+		return -1
+	}
+	return 1
+}
+
 //evaluate identifier expression
 func evalIdent(id *ast.Identifier, line int, env *object.Env) object.Object {
 	if val, found := env.GetEnv(id.Value); found {
@@ -478,41 +898,161 @@ func evalIdent(id *ast.Identifier, line int, env *object.Env) object.Object {
 	return errorwords.SetError(210, line, id.Value)
 }
 
+//checkDefinable check name can be defined by make,const,func in env(not constant,not builtin)
+func checkDefinable(name string, line int, env *object.Env) object.Object {
+	if env.IsLocalConst(name) {
+		return errorwords.SetError(212, line, name)
+	}
+	if _, found := builtIns[name]; found {
+		return errorwords.SetError(213, line, name)
+	}
+	return nil
+}
+
 //execute function object and return ReturnValue
-func exeFunction(fn object.Object, args []object.Object, line int) object.Object {
+func exeFunction(fn object.Object, args []object.Object, named *object.Map, line int) object.Object {
 	switch funcObj := fn.(type) {
 	case *object.Function:
-		newEnv := addFuncEnv(funcObj, args, line)
-		if newEnv == nil {
-			return nil
+		//tail call(return f(...) in f) is executed by this loop,not nested Eval
+		for count := 1; ; count++ {
+			newEnv, err := addFuncEnv(funcObj, args, named, line)
+			if err != nil {
+				return err
+			}
+			evaled := Eval(funcObj.Process, newEnv)
+			rv, ok := evaled.(*object.ReturnValue)
+			if !ok || rv.TailCall == nil {
+				return getRV(evaled)
+			}
+			log.SetLog(rv.Line, funcName(funcObj), "再実行", fmt.Sprintf("末尾呼び出しのため関数を最初から実行(%v回目)", count))
+			args, named = rv.TailCall.Args, rv.TailCall.Named
 		}
-		evaled := Eval(funcObj.Process, newEnv)
-		return getRV(evaled)
 	case *object.BuiltIn:
+		if named != nil {
+			return errorwords.SetError(236, line, named.Keys[0])
+		}
 		return funcObj.Func(line, args...)
+	case *object.RecordType:
+		return newRecord(funcObj, args, named, line)
 	}
 	return errorwords.SetError(231, line, fn.Type())
 }
 
+//evalTailCall If return value is call of running function(return f(...) in f),evaluate arguments only and return TailCall
+func evalTailCall(r *ast.Return, env *object.Env) object.Object {
+	call, ok := r.Value.(*ast.Call)
+	if !ok || !r.Tail {
+		return nil
+	}
+	ident, ok := call.Function.(*ast.Identifier)
+	callee := env.Callee()
+	if !ok || callee == nil {
+		return nil
+	}
+	if fn, found := env.GetEnv(ident.Value); !found || fn != callee {
+		return nil
+	}
+	args := evalExps(call.Arguments, env)
+	if len(args) == 1 && isError(args[0]) {
+		return args[0]
+	}
+	named, err := evalNamedArgs(call.Named, env)
+	if err != nil {
+		return err
+	}
+	log.SetLog(r.Token.Line, call.String(), "末尾呼び出し", "関数("+ident.Value+")を呼び出し元で繰り返し実行する")
+	return &object.ReturnValue{Line: r.Token.Line, TailCall: &object.TailCall{Args: args, Named: named}}
+}
+
 //Add new Environment(in function) and set function parameters ,args in this env
-func addFuncEnv(fn *object.Function, args []object.Object, line int) *object.Env {
+//(named arguments,rest parameter and default values are set here too)
+func addFuncEnv(fn *object.Function, args []object.Object, named *object.Map, line int) (*object.Env, object.Object) {
 	nenv := object.AddEnv(fn.Env)
-	if len(args) != len(fn.Params) {
-		errorwords.SetError(232, line, len(args), len(fn.Params))
-		return nil
+	nenv.SetCallee(fn)
+	name := funcName(fn)
+	if len(args) > len(fn.Params) && fn.Rest == nil {
+		return nil, errorwords.SetError(232, line, len(args), len(fn.Params))
 	}
+	bound := map[string]bool{}
 	for i, param := range fn.Params {
+		if i >= len(args) {
+			break
+		}
 		log.SetLog(param.Token.Line, param.String(), args[i].Inspect(), "関数のパラメータに引数を代入")
 		nenv.SetEnv(param.Value, args[i])
+		bound[param.Value] = true
+	}
+	if fn.Rest != nil {
+		rest := &object.Array{Elements: []object.Object{}, Line: line}
+		if len(args) > len(fn.Params) {
+			rest.Elements = append(rest.Elements, args[len(fn.Params):]...)
+		}
+		log.SetLog(fn.Rest.Token.Line, "..."+fn.Rest.String(), rest.Inspect(), "可変長引数に残りの引数をまとめて代入")
+		nenv.SetEnv(fn.Rest.Value, rest)
+	}
+	if named != nil {
+		for _, key := range named.Keys {
+			if !hasParam(fn, key) {
+				return nil, errorwords.SetError(233, line, name, key)
+			}
+			if bound[key] {
+				return nil, errorwords.SetError(234, line, name, key)
+			}
+			val, _ := named.Get(key)
+			log.SetLog(line, key, val.Inspect(), "関数のパラメータに名前付き引数を代入")
+			nenv.SetEnv(key, val)
+			bound[key] = true
+		}
+	}
+	for i, param := range fn.Params {
+		if bound[param.Value] {
+			continue
+		}
+		if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+			if named == nil {
+				return nil, errorwords.SetError(232, line, len(args), len(fn.Params))
+			}
+			return nil, errorwords.SetError(235, line, name, param.Value)
+		}
+		//default value can use parameters before it
+		val := Eval(fn.Defaults[i], nenv)
+		if isError(val) {
+			return nil, val
+		}
+		log.SetLog(param.Token.Line, param.String(), val.Inspect(), "関数のパラメータにデフォルト値を代入")
+		nenv.SetEnv(param.Value, val)
 	}
-	return nenv
+	return nenv, nil
 }
+
+//funcName Get name of function(anonymous function is "(無名関数)")
+func funcName(fn *object.Function) string {
+	if fn.Name == nil {
+		return "(無名関数)"
+	}
+	return fn.Name.Value
+}
+
+//hasParam check function has parameter(not rest parameter)
+func hasParam(fn *object.Function, name string) bool {
+	for _, param := range fn.Params {
+		if param.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
 func evalIndex(left, index object.Object, line int) object.Object {
 	switch {
 	case left.Type() == object.ArrayOBJ && index.Type() == object.IntOBJ:
 		return evalArrayIndex(left, index, line)
 	case left.Type() == object.StringOBJ && index.Type() == object.IntOBJ:
 		return evalStringIndex(left, index, line)
+	case left.Type() == object.MapOBJ:
+		return evalMapIndex(left, index, line)
+	case left.Type() == object.ErrorValueOBJ:
+		return evalErrorValueIndex(left, index, line)
 	default:
 		if index.Type() != object.IntOBJ {
 			return errorwords.SetError(401, line)
@@ -520,6 +1060,103 @@ func evalIndex(left, index object.Object, line int) object.Object {
 		return errorwords.SetError(400, line, left.Type())
 	}
 }
+
+//evaluate "arr[i] = value" (Array and Map)
+func evalIndexAssign(ia *ast.IndexAssign, env *object.Env, line int) object.Object {
+	left := Eval(ia.Target.Left, env)
+	if isError(left) {
+		return left
+	}
+	index := Eval(ia.Target.Index, env)
+	if isError(index) {
+		return index
+	}
+	val := Eval(ia.Value, env)
+	if isError(val) {
+		return val
+	}
+	if ia.Operator != "" {
+		//arr[i] += 1 is evaluated as arr[i] = arr[i] + 1
+		current := evalIndex(left, index, line)
+		if isError(current) {
+			return current
+		}
+		val = evalInfix(ia.Operator, current, val, line)
+		if isError(val) {
+			return val
+		}
+	}
+	switch target := left.(type) {
+	case *object.Array:
+		ix, ok := index.(*object.Int)
+		if !ok {
+			return errorwords.SetError(401, line)
+		}
+		end := int64(len(target.Elements) - 1)
+		if ix.Value < 0 {
+			return errorwords.SetError(402, line)
+		}
+		if ix.Value > end {
+			return errorwords.SetError(403, line, ix.Value, end)
+		}
+		if containsObject(val, target) {
+			return errorwords.SetError(407, line, ia.Target.Left.String())
+		}
+		target.Elements[ix.Value] = val
+		log.SetLog(line, ia.Target.String(), val.Inspect(), fmt.Sprintf("配列の%v番目に%vを代入", ix.Value, val.Inspect()))
+	case *object.Map:
+		key, ok := index.(*object.String)
+		if !ok {
+			return errorwords.SetError(405, line)
+		}
+		if containsObject(val, target) {
+			return errorwords.SetError(407, line, ia.Target.Left.String())
+		}
+		target.Set(key.Value, val)
+		log.SetLog(line, ia.Target.String(), val.Inspect(), fmt.Sprintf("連想配列のキー%vに%vを代入", key.Inspect(), val.Inspect()))
+	default:
+		return errorwords.SetError(406, line, left.Type())
+	}
+	return object.NULL
+}
+
+//containsObject check obj is target or contains target in its elements(arrays,maps,records are searched deeply)
+func containsObject(obj object.Object, target object.Object) bool {
+	return containsObjectIn(obj, target, map[object.Object]bool{})
+}
+
+//containsObjectIn search target in obj(visited objects are not searched again)
+func containsObjectIn(obj object.Object, target object.Object, visited map[object.Object]bool) bool {
+	if obj == target {
+		return true
+	}
+	if visited[obj] {
+		return false
+	}
+	visited[obj] = true
+	switch obj := obj.(type) {
+	case *object.Array:
+		for _, el := range obj.Elements {
+			if containsObjectIn(el, target, visited) {
+				return true
+			}
+		}
+	case *object.Map:
+		for _, val := range obj.Pairs {
+			if containsObjectIn(val, target, visited) {
+				return true
+			}
+		}
+	case *object.Record:
+		for _, val := range obj.Fields {
+			if containsObjectIn(val, target, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func evalArrayIndex(left, index object.Object, line int) object.Object {
 	array := left.(*object.Array)
 	ix := index.(*object.Int).Value
@@ -533,6 +1170,39 @@ func evalArrayIndex(left, index object.Object, line int) object.Object {
 	log.SetLog(line, fmt.Sprintf("%v[%v]", array.Inspect(), ix), array.Elements[ix].Inspect(), "配列から値をとりだす")
 	return array.Elements[ix]
 }
+func evalMapIndex(left, index object.Object, line int) object.Object {
+	m := left.(*object.Map)
+	key, ok := index.(*object.String)
+	if !ok {
+		return errorwords.SetError(405, line)
+	}
+	val, found := m.Get(key.Value)
+	if !found {
+		return errorwords.SetError(404, line, key.Value)
+	}
+	log.SetLog(line, fmt.Sprintf("%v[%v]", m.Inspect(), key.Inspect()), val.Inspect(), "連想配列から値をとりだす")
+	return val
+}
+func evalErrorValueIndex(left, index object.Object, line int) object.Object {
+	ev := left.(*object.ErrorValue)
+	key, ok := index.(*object.String)
+	if !ok {
+		return errorwords.SetError(405, line)
+	}
+	var val object.Object
+	switch key.Value {
+	case "code":
+		val = &object.Int{Value: int64(ev.Code), Line: line}
+	case "message":
+		val = &object.String{Value: ev.Message, Line: line}
+	case "line":
+		val = &object.Int{Value: int64(ev.Line), Line: line}
+	default:
+		return errorwords.SetError(404, line, key.Value)
+	}
+	log.SetLog(line, fmt.Sprintf("%v[%v]", ev.Inspect(), key.Inspect()), val.Inspect(), "エラーから値をとりだす")
+	return val
+}
 func evalStringIndex(left, index object.Object, line int) object.Object {
 	str := left.(*object.String)
 	ix := index.(*object.Int).Value
@@ -609,6 +1279,9 @@ func floatCalc(lval float64, operator string, rval float64, line int) object.Obj
 		log.SetLog(line, infixFloatString(lval, " * ", rval), strconv.FormatFloat(lval*rval, 'f', -1, 64), "計算")
 		return &object.Float{Value: lval * rval, Line: line}
 	case "/":
+		if rval == 0 {
+			return errorwords.SetError(209, line, infixFloatString(lval, " / ", rval))
+		}
 		log.SetLog(line, infixFloatString(lval, " / ", rval), strconv.FormatFloat(lval/rval, 'f', -1, 64), "計算")
 		return &object.Float{Value: lval / rval, Line: line}
 	case "%":
@@ -640,9 +1313,22 @@ func floatCalc(lval float64, operator string, rval float64, line int) object.Obj
 func infixFloatString(left float64, operator string, right float64) string {
 	return strconv.FormatFloat(left, 'f', -1, 64) + operator + strconv.FormatFloat(right, 'f', -1, 64)
 }
-func isStopType(obj object.Object) bool {
-	if obj != nil && (obj.Type() == object.StopOBJ || obj.Type() == object.ReturnOBJ || obj.Type() == object.ErrorOBJ) {
-		return true
+
+//check result of loop process.If loop should end,return true.stop,next for this loop are consumed
+func loopControl(obj object.Object, label *ast.Identifier) (bool, object.Object) {
+	switch ctl := obj.(type) {
+	case *object.Stop:
+		if ctl.Label == "" || (label != nil && ctl.Label == label.Value) {
+			return true, object.NULL
+		}
+		return true, obj
+	case *object.Next:
+		if ctl.Label == "" || (label != nil && ctl.Label == label.Value) {
+			return false, object.NULL
+		}
+		return true, obj
+	case *object.ReturnValue, *object.ERROR:
+		return true, obj
 	}
-	return false
+	return false, obj
 }
@@ -0,0 +1,202 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//toJSON TOJSON(value,pretty) convert object to JSON string
+func toJSON(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "TOJSON", "1または2")
+	}
+	pretty := false
+	if len(args) == 2 {
+		b, ok := args[1].(*object.Bool)
+		if !ok {
+			return errorwords.SetError(309, line, "TOJSON", 2, "真偽値")
+		}
+		pretty = b.Value
+	}
+	var out bytes.Buffer
+	if err := writeJSON(&out, args[0], line); err != nil {
+		return err
+	}
+	if pretty {
+		var indented bytes.Buffer
+		json.Indent(&indented, out.Bytes(), "", "  ")
+		out = indented
+	}
+	result := out.String()
+	log.SetLog(line, "TOJSON("+args[0].Inspect()+")", `"`+result+`"`, "組み込み関数TOJSONを実行")
+	return &object.String{Value: result, Line: line}
+}
+
+//writeJSON write object as JSON.If object can't convert,return error object
+func writeJSON(out *bytes.Buffer, obj object.Object, line int) object.Object {
+	switch obj := obj.(type) {
+	case *object.Int:
+		out.WriteString(strconv.FormatInt(obj.Value, 10))
+	case *object.BigInt:
+		out.WriteString(obj.Value.String())
+	case *object.Rational:
+		out.WriteString(strconv.FormatFloat(ratToFloat(obj.Value), 'f', -1, 64))
+	case *object.Float:
+		if math.IsInf(obj.Value, 0) || math.IsNaN(obj.Value) {
+			return errorwords.SetError(311, line, obj.Inspect())
+		}
+		out.WriteString(strconv.FormatFloat(obj.Value, 'f', -1, 64))
+	case *object.Null:
+		out.WriteString("null")
+	case *object.Bool:
+		out.WriteString(booltoString(obj.Value))
+	case *object.String:
+		out.WriteString(jsonString(obj.Value))
+	case *object.Array:
+		out.WriteString("[")
+		for i, e := range obj.Elements {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			if err := writeJSON(out, e, line); err != nil {
+				return err
+			}
+		}
+		out.WriteString("]")
+	case *object.Map:
+		out.WriteString("{")
+		for i, k := range obj.Keys {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			out.WriteString(jsonString(k) + ":")
+			if err := writeJSON(out, obj.Pairs[k], line); err != nil {
+				return err
+			}
+		}
+		out.WriteString("}")
+	default:
+		return errorwords.SetError(311, line, obj.Type())
+	}
+	return nil
+}
+
+//jsonString quote string for JSON(without HTML escape)
+func jsonString(s string) string {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimRight(out.String(), "\n")
+}
+
+//fromJSON FROMJSON(string) convert JSON string to object
+func fromJSON(line int, args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return errorwords.SetError(300, line, "FROMJSON", 1)
+	}
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, "FROMJSON", "文字列")
+	}
+	dec := json.NewDecoder(strings.NewReader(str.Value))
+	dec.UseNumber()
+	result, err := decodeJSON(dec, line)
+	if err == nil {
+		if _, end := dec.Token(); end != io.EOF {
+			return errorwords.SetError(310, line, jsonLine(str.Value, dec.InputOffset()), "値の後に余分な文字があります")
+		}
+	}
+	if err != nil {
+		offset := dec.InputOffset()
+		message := err.Error()
+		if se, ok := err.(*json.SyntaxError); ok {
+			offset = se.Offset
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			offset = int64(len(str.Value))
+			message = "JSONが途中で終わっています"
+		}
+		return errorwords.SetError(310, line, jsonLine(str.Value, offset), message)
+	}
+	log.SetLog(line, "FROMJSON("+str.Inspect()+")", result.Inspect(), "組み込み関数FROMJSONを実行")
+	return result
+}
+
+//decodeJSON read one JSON value from decoder and make object
+func decodeJSON(dec *json.Decoder, line int) (object.Object, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			array := &object.Array{Elements: []object.Object{}, Line: line}
+			for dec.More() {
+				el, err := decodeJSON(dec, line)
+				if err != nil {
+					return nil, err
+				}
+				array.Elements = append(array.Elements, el)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return array, nil
+		}
+		m := object.NewMap(line)
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSON(dec, line)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(key.(string), value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case json.Number:
+		if i, err := strconv.ParseInt(string(t), 10, 64); err == nil {
+			return &object.Int{Value: i, Line: line}, nil
+		}
+		if b, ok := new(big.Int).SetString(string(t), 10); ok {
+			return &object.BigInt{Value: b, Line: line}, nil
+		}
+		f, err := strconv.ParseFloat(string(t), 64)
+		if err != nil {
+			return nil, err
+		}
+		return &object.Float{Value: f, Line: line}, nil
+	case string:
+		return &object.String{Value: t, Line: line}, nil
+	case bool:
+		return &object.Bool{Value: t, Line: line}, nil
+	case nil:
+		return object.NULL, nil
+	default:
+		return nil, errors.New("対応していない値です")
+	}
+}
+
+//jsonLine Get line number of offset in JSON source
+func jsonLine(src string, offset int64) int {
+	if offset > int64(len(src)) {
+		offset = int64(len(src))
+	}
+	return strings.Count(src[:offset], "\n") + 1
+}
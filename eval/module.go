@@ -0,0 +1,131 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/ast"
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/lexer"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"github.com/hmwri/peridot/parser"
+	"github.com/hmwri/peridot/stdlib"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//modules imported modules(key is absolute path or "stdlib:name").Each module is evaluated once
+var modules = map[string]*object.Module{}
+
+//importing paths of modules now being evaluated(for cycle detection)
+var importing = []string{}
+
+//currentFile file now being evaluated(import path is relative to it)
+var currentFile = ""
+
+//SetMainFile Set the file run first(import in it is relative to this file,and importing it again is cycle)
+func SetMainFile(path string) {
+	currentFile = path
+	importing = []string{}
+	if abs, err := filepath.Abs(path); err == nil {
+		importing = append(importing, abs)
+	}
+}
+
+//evalImport evaluate "import "utils.pri" as u"
+func evalImport(im *ast.Import, env *object.Env, line int) object.Object {
+	if err := checkDefinable(im.Name.Value, line, env); err != nil {
+		return err
+	}
+	//import "list" (no extension) is standard library
+	std := filepath.Ext(im.Path) == ""
+	key := "stdlib:" + im.Path
+	if !std {
+		path := im.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(currentFile), path)
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return errorwords.SetError(261, line, im.Path)
+		}
+		key = abs
+	}
+	mod, found := modules[key]
+	if !found {
+		var loaded object.Object
+		if std {
+			loaded = loadStdlib(key, im.Path, line)
+		} else {
+			loaded = loadModule(key, im.Path, line)
+		}
+		if isError(loaded) {
+			return loaded
+		}
+		mod = loaded.(*object.Module)
+		modules[key] = mod
+	}
+	log.SetLog(line, im.Name.String(), mod.Inspect(), "モジュールを読み込み(名前:"+im.Name.String()+")")
+	env.SetEnv(im.Name.Value, &object.Module{Name: im.Name.Value, Path: mod.Path, Env: mod.Env})
+	return object.NULL
+}
+
+//loadModule read,parse and evaluate file(abs is absolute path,name is path written in import)
+func loadModule(abs string, name string, line int) object.Object {
+	src, err := os.ReadFile(abs)
+	if err != nil {
+		return errorwords.SetError(261, line, name)
+	}
+	return evalModule(abs, name, string(src), line)
+}
+
+//loadStdlib parse and evaluate module of standard library(evaluated when first imported)
+func loadStdlib(key string, name string, line int) object.Object {
+	src, found := stdlib.Source(name)
+	if !found {
+		return errorwords.SetError(263, line, name, strings.Join(stdlib.Names(), ", "), name)
+	}
+	return evalModule(key, name, src, line)
+}
+
+//evalModule parse and evaluate source of module in new env
+func evalModule(path string, name string, src string, line int) object.Object {
+	for i, p := range importing {
+		if p == path {
+			cycle := []string{}
+			for _, c := range importing[i:] {
+				cycle = append(cycle, filepath.Base(c))
+			}
+			cycle = append(cycle, filepath.Base(path))
+			return errorwords.SetError(260, line, strings.Join(cycle, " -> "))
+		}
+	}
+	//parser.New resets errors of running program
+	errs := errorwords.Errp
+	p := parser.New(lexer.New(src))
+	program := p.Parse()
+	errorwords.Errp = errs
+	if perrs := p.GetError(); len(perrs) != 0 {
+		return errorwords.SetError(262, line, name, perrs[0].Message)
+	}
+	importing = append(importing, path)
+	file := currentFile
+	currentFile = path
+	env := object.NewEnv()
+	result := Eval(program, env)
+	currentFile = file
+	importing = importing[:len(importing)-1]
+	if isError(result) {
+		return result
+	}
+	return &object.Module{Name: name, Path: path, Env: env}
+}
+
+//evalModuleField evaluate "u.name"
+func evalModuleField(mod *object.Module, name string, line int) object.Object {
+	val, found := mod.Env.GetEnv(name)
+	if !found {
+		return errorwords.SetError(257, line, mod.Name, name)
+	}
+	log.SetLog(line, mod.Name+"."+name, val.Inspect(), "モジュール("+mod.Name+")の"+name+"を参照")
+	return val
+}
@@ -0,0 +1,68 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"math/big"
+)
+
+//Exact exact mode(division of integers is exact fraction)
+var Exact = false
+
+//ratToFloat convert fraction to float64
+func ratToFloat(v *big.Rat) float64 {
+	f, _ := v.Float64()
+	return f
+}
+
+//ratCalc calculate fractions(result whose denominator is 1 becomes Int)
+func ratCalc(lval *big.Rat, operator string, rval *big.Rat, line int) object.Object {
+	expr := lval.RatString() + " " + operator + " " + rval.RatString()
+	if (operator == "/" || operator == "%") && rval.Sign() == 0 {
+		return errorwords.SetError(209, line, expr)
+	}
+	var result *big.Rat
+	switch operator {
+	case "+":
+		result = new(big.Rat).Add(lval, rval)
+	case "-":
+		result = new(big.Rat).Sub(lval, rval)
+	case "*":
+		result = new(big.Rat).Mul(lval, rval)
+	case "/":
+		result = new(big.Rat).Quo(lval, rval)
+	case "%":
+		//lval - rval * (integer part of lval / rval)
+		quo := new(big.Rat).Quo(lval, rval)
+		trunc := new(big.Int).Quo(quo.Num(), quo.Denom())
+		result = new(big.Rat).Sub(lval, new(big.Rat).Mul(rval, new(big.Rat).SetInt(trunc)))
+	case "<", ">", "<=", ">=", "!=", "==":
+		return bigCompare(lval.Cmp(rval), operator, expr, line)
+	default:
+		return errorwords.SetError(204, line, operator)
+	}
+	log.SetLog(line, expr, result.RatString(), "計算(分数)")
+	return object.NewRational(result, line)
+}
+
+//evalRatInfix Infix Expression Evaluator(left is fraction)
+func evalRatInfix(operator string, lval *big.Rat, right object.Object, line int) object.Object {
+	switch rval := right.GetVal().(type) {
+	case *big.Rat:
+		return ratCalc(lval, operator, rval, line)
+	case *big.Int:
+		return ratCalc(lval, operator, new(big.Rat).SetInt(rval), line)
+	case int64:
+		return ratCalc(lval, operator, new(big.Rat).SetInt64(rval), line)
+	case float64:
+		return floatCalc(ratToFloat(lval), operator, rval, line)
+	case string:
+		if operator != "+" {
+			return errorwords.SetError(207, line)
+		}
+		log.SetLog(line, lval.RatString()+" + "+rval, lval.RatString()+rval, "文字列結合")
+		return &object.String{Value: lval.RatString() + rval, Line: line}
+	}
+	return errorwords.SetError(203, line, "分数", "数,文字列以外")
+}
@@ -0,0 +1,126 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/ast"
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+)
+
+//evalTypeDecl evaluate "type Point { x, y }"
+func evalTypeDecl(td *ast.TypeDecl, env *object.Env, line int) object.Object {
+	if err := checkDefinable(td.Name.Value, line, env); err != nil {
+		return err
+	}
+	rt := &object.RecordType{Name: td.Name.Value, Fields: []string{}, Methods: map[string]*object.Function{}, Line: line}
+	for _, f := range td.Fields {
+		rt.Fields = append(rt.Fields, f.Value)
+	}
+	log.SetLog(line, td.Name.String(), rt.Inspect(), "型を定義(名前:"+td.Name.String()+")")
+	env.SetEnv(td.Name.Value, rt)
+	return object.NULL
+}
+
+//evalMethodDecl evaluate "func (p Point) name() {}" and add method to the type
+func evalMethodDecl(fn *object.Function, env *object.Env, line int) object.Object {
+	obj, found := env.GetEnv(fn.RecvType.Value)
+	rt, ok := obj.(*object.RecordType)
+	if !found || !ok {
+		return errorwords.SetError(255, line, fn.RecvType.Value, fn.RecvType.Value)
+	}
+	if rt.HasField(fn.Name.Value) {
+		return errorwords.SetError(256, line, rt.Name, fn.Name.Value)
+	}
+	log.SetLog(line, rt.Name+"."+fn.Name.String(), fn.Inspect(), "メソッドを定義(型:"+rt.Name+",名前:"+fn.Name.String()+")")
+	rt.Methods[fn.Name.Value] = fn
+	return object.NULL
+}
+
+//newRecord make record by calling type(Point(1, 2) or Point(x = 1, y = 2))
+func newRecord(rt *object.RecordType, args []object.Object, named *object.Map, line int) object.Object {
+	if len(args) > len(rt.Fields) {
+		return errorwords.SetError(251, line, rt.Name, len(rt.Fields), len(args))
+	}
+	rec := &object.Record{RecordType: rt, Fields: map[string]object.Object{}, Line: line}
+	for i, arg := range args {
+		rec.Fields[rt.Fields[i]] = arg
+	}
+	if named != nil {
+		for _, key := range named.Keys {
+			if !rt.HasField(key) {
+				return errorwords.SetError(250, line, rt.Name, key)
+			}
+			if _, found := rec.Fields[key]; found {
+				return errorwords.SetError(253, line, rt.Name, key)
+			}
+			rec.Fields[key], _ = named.Get(key)
+		}
+	}
+	for _, f := range rt.Fields {
+		if val, found := rec.Fields[f]; !found || val == nil {
+			return errorwords.SetError(252, line, rt.Name, f)
+		}
+	}
+	log.SetLog(line, rt.Name, rec.Inspect(), "型"+rt.Name+"の値を作成")
+	return rec
+}
+
+//evalField evaluate "p.x".If name is method,return function whose receiver is set
+func evalField(left object.Object, name string, line int) object.Object {
+	switch left := left.(type) {
+	case *object.Record:
+		if val, found := left.Fields[name]; found {
+			log.SetLog(line, left.RecordType.Name+"."+name, val.Inspect(), "フィールド("+name+")を参照")
+			return val
+		}
+		if method, found := left.RecordType.Methods[name]; found {
+			bound := *method
+			bound.Env = object.AddEnv(method.Env)
+			bound.Env.SetEnv(method.Receiver.Value, left)
+			return &bound
+		}
+		return errorwords.SetError(250, line, left.RecordType.Name, name)
+	case *object.Module:
+		return evalModuleField(left, name, line)
+	case *object.ErrorValue:
+		switch name {
+		case "code", "message", "line":
+			return evalErrorValueIndex(left, &object.String{Value: name, Line: line}, line)
+		}
+		return errorwords.SetError(250, line, "エラー", name)
+	}
+	return errorwords.SetError(254, line, left.Type())
+}
+
+//evalFieldAssign evaluate "p.x = value"
+func evalFieldAssign(fa *ast.FieldAssign, env *object.Env, line int) object.Object {
+	left := Eval(fa.Target.Left, env)
+	if isError(left) {
+		return left
+	}
+	val := Eval(fa.Value, env)
+	if isError(val) {
+		return val
+	}
+	name := fa.Target.Name.Value
+	rec, ok := left.(*object.Record)
+	if !ok {
+		return errorwords.SetError(406, line, left.Type())
+	}
+	current, found := rec.Fields[name]
+	if !found {
+		return errorwords.SetError(250, line, rec.RecordType.Name, name)
+	}
+	if fa.Operator != "" {
+		val = evalInfix(fa.Operator, current, val, line)
+		if isError(val) {
+			return val
+		}
+	}
+	if containsObject(val, rec) {
+		return errorwords.SetError(407, line, fa.Target.Left.String())
+	}
+	rec.Fields[name] = val
+	log.SetLog(line, rec.RecordType.Name+"."+name, val.Inspect(), "フィールド("+name+")に"+val.Inspect()+"を代入")
+	return object.NULL
+}
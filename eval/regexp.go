@@ -0,0 +1,137 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"regexp"
+)
+
+//regexpCacheSize max number of cached patterns(cache is cleared when it is full)
+const regexpCacheSize = 64
+
+//regexpCache compiled patterns
+var regexpCache = map[string]*regexp.Regexp{}
+
+//compileRegexp compile pattern(use cache).If pattern is invalid,return error object
+func compileRegexp(name string, pattern object.Object, line int) (*regexp.Regexp, object.Object) {
+	str, ok := pattern.(*object.String)
+	if !ok {
+		return nil, errorwords.SetError(301, line, name, "文字列(正規表現)")
+	}
+	if re, found := regexpCache[str.Value]; found {
+		return re, nil
+	}
+	re, err := regexp.Compile(str.Value)
+	if err != nil {
+		return nil, errorwords.SetError(319, line, name, str.Value, err.Error())
+	}
+	//patterns made at runtime(e.g."^" + name + "$" in loop) must not grow cache forever
+	if len(regexpCache) >= regexpCacheSize {
+		regexpCache = map[string]*regexp.Regexp{}
+	}
+	regexpCache[str.Value] = re
+	return re, nil
+}
+
+//regexpTarget get string argument at pos
+func regexpTarget(name string, args []object.Object, pos int, line int) (string, object.Object) {
+	str, ok := args[pos-1].(*object.String)
+	if !ok {
+		return "", errorwords.SetError(309, line, name, pos, "文字列")
+	}
+	return str.Value, nil
+}
+
+//stringsToArray []string to Array of String
+func stringsToArray(strs []string, line int) *object.Array {
+	array := &object.Array{Elements: []object.Object{}, Line: line}
+	for _, s := range strs {
+		array.Elements = append(array.Elements, &object.String{Value: s, Line: line})
+	}
+	return array
+}
+
+//match MATCH(pattern,string) return [whole match,captures...].If not matched,return []
+func match(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "MATCH", 2)
+	}
+	re, err := compileRegexp("MATCH", args[0], line)
+	if err != nil {
+		return err
+	}
+	target, err := regexpTarget("MATCH", args, 2, line)
+	if err != nil {
+		return err
+	}
+	result := stringsToArray(re.FindStringSubmatch(target), line)
+	log.SetLog(line, "MATCH("+args[0].Inspect()+", "+args[1].Inspect()+")", result.Inspect(), "組み込み関数MATCHを実行")
+	return result
+}
+
+//findAll FINDALL(pattern,string) return all matches.If pattern has captures,each match is [whole match,captures...]
+func findAll(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "FINDALL", 2)
+	}
+	re, err := compileRegexp("FINDALL", args[0], line)
+	if err != nil {
+		return err
+	}
+	target, err := regexpTarget("FINDALL", args, 2, line)
+	if err != nil {
+		return err
+	}
+	var result *object.Array
+	if re.NumSubexp() == 0 {
+		result = stringsToArray(re.FindAllString(target, -1), line)
+	} else {
+		result = &object.Array{Elements: []object.Object{}, Line: line}
+		for _, m := range re.FindAllStringSubmatch(target, -1) {
+			result.Elements = append(result.Elements, stringsToArray(m, line))
+		}
+	}
+	log.SetLog(line, "FINDALL("+args[0].Inspect()+", "+args[1].Inspect()+")", result.Inspect(), "組み込み関数FINDALLを実行")
+	return result
+}
+
+//replaceAll REPLACEALL(pattern,string,replacement) replace all matches($1 is first capture)
+func replaceAll(line int, args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return errorwords.SetError(300, line, "REPLACEALL", 3)
+	}
+	re, err := compileRegexp("REPLACEALL", args[0], line)
+	if err != nil {
+		return err
+	}
+	target, err := regexpTarget("REPLACEALL", args, 2, line)
+	if err != nil {
+		return err
+	}
+	repl, err := regexpTarget("REPLACEALL", args, 3, line)
+	if err != nil {
+		return err
+	}
+	result := &object.String{Value: re.ReplaceAllString(target, repl), Line: line}
+	log.SetLog(line, "REPLACEALL("+args[0].Inspect()+", "+args[1].Inspect()+")", result.Inspect(), "組み込み関数REPLACEALLを実行")
+	return result
+}
+
+//splitRe SPLITRE(pattern,string) split string by pattern
+func splitRe(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "SPLITRE", 2)
+	}
+	re, err := compileRegexp("SPLITRE", args[0], line)
+	if err != nil {
+		return err
+	}
+	target, err := regexpTarget("SPLITRE", args, 2, line)
+	if err != nil {
+		return err
+	}
+	result := stringsToArray(re.Split(target, -1), line)
+	log.SetLog(line, "SPLITRE("+args[0].Inspect()+", "+args[1].Inspect()+")", result.Inspect(), "組み込み関数SPLITREを実行")
+	return result
+}
@@ -0,0 +1,265 @@
+package eval
+
+import (
+	"fmt"
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/log"
+	"github.com/hmwri/peridot/object"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//clock returns current time(NOW,TODAY use this)
+var clock = time.Now
+
+//defaultTimeLayout layout used when FORMATTIME,PARSETIME have no layout
+const defaultTimeLayout = "YYYY-MM-DD hh:mm:ss"
+
+//timePlaceholders PeriDot layout placeholders(other text in layout is copied as it is)
+var timePlaceholders = []string{"YYYY", "MM", "DD", "hh", "mm", "ss"}
+
+//layoutPart a part of layout(placeholder or literal text)
+type layoutPart struct {
+	placeholder string
+	text        string
+}
+
+//splitLayout split layout into placeholders and literal text
+func splitLayout(layout string) []layoutPart {
+	parts := []layoutPart{}
+	text := ""
+	for i := 0; i < len(layout); {
+		found := ""
+		for _, ph := range timePlaceholders {
+			if strings.HasPrefix(layout[i:], ph) {
+				found = ph
+				break
+			}
+		}
+		if found == "" {
+			text += layout[i : i+1]
+			i++
+			continue
+		}
+		if text != "" {
+			parts = append(parts, layoutPart{text: text})
+			text = ""
+		}
+		parts = append(parts, layoutPart{placeholder: found})
+		i += len(found)
+	}
+	if text != "" {
+		parts = append(parts, layoutPart{text: text})
+	}
+	return parts
+}
+
+//formatLayout convert time to string by PeriDot layout
+func formatLayout(t time.Time, layout string) string {
+	var out strings.Builder
+	for _, part := range splitLayout(layout) {
+		switch part.placeholder {
+		case "YYYY":
+			out.WriteString(fmt.Sprintf("%04d", t.Year()))
+		case "MM":
+			out.WriteString(fmt.Sprintf("%02d", int(t.Month())))
+		case "DD":
+			out.WriteString(fmt.Sprintf("%02d", t.Day()))
+		case "hh":
+			out.WriteString(fmt.Sprintf("%02d", t.Hour()))
+		case "mm":
+			out.WriteString(fmt.Sprintf("%02d", t.Minute()))
+		case "ss":
+			out.WriteString(fmt.Sprintf("%02d", t.Second()))
+		default:
+			out.WriteString(part.text)
+		}
+	}
+	return out.String()
+}
+
+//parseLayout convert string to time by PeriDot layout(ok is false if string doesn't match layout)
+func parseLayout(value string, layout string, loc *time.Location) (time.Time, bool) {
+	//missing fields are year 0,January 1st,00:00:00
+	fields := map[string]int{"YYYY": 0, "MM": 1, "DD": 1, "hh": 0, "mm": 0, "ss": 0}
+	for _, part := range splitLayout(layout) {
+		if part.placeholder == "" {
+			if !strings.HasPrefix(value, part.text) {
+				return time.Time{}, false
+			}
+			value = value[len(part.text):]
+			continue
+		}
+		width := len(part.placeholder)
+		if len(value) < width {
+			return time.Time{}, false
+		}
+		num, err := strconv.Atoi(value[:width])
+		if err != nil || strings.ContainsAny(value[:width], "+-") {
+			return time.Time{}, false
+		}
+		fields[part.placeholder] = num
+		value = value[width:]
+	}
+	if value != "" {
+		return time.Time{}, false
+	}
+	t := time.Date(fields["YYYY"], time.Month(fields["MM"]), fields["DD"], fields["hh"], fields["mm"], fields["ss"], 0, loc)
+	//time.Date normalizes out of range values(2月30日 -> 3月1日),so they are rejected
+	if t.Year() != fields["YYYY"] || int(t.Month()) != fields["MM"] || t.Day() != fields["DD"] ||
+		t.Hour() != fields["hh"] || t.Minute() != fields["mm"] || t.Second() != fields["ss"] {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+//jaWeekdays Japanese weekday names(Sunday first)
+var jaWeekdays = [...]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+
+//SetClock Replace the clock used by NOW and TODAY(nil restores the real clock)
+func SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	clock = now
+}
+
+//now NOW() return current date and time
+func now(line int, args ...object.Object) object.Object {
+	if len(args) != 0 {
+		return errorwords.SetError(300, line, "NOW", 0)
+	}
+	result := &object.Time{Value: clock(), Line: line}
+	log.SetLog(line, "", result.Inspect(), "組み込み関数NOWを実行")
+	return result
+}
+
+//today TODAY() return today(00:00:00)
+func today(line int, args ...object.Object) object.Object {
+	if len(args) != 0 {
+		return errorwords.SetError(300, line, "TODAY", 0)
+	}
+	t := clock()
+	result := &object.Time{Value: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), Line: line}
+	log.SetLog(line, "", result.Inspect(), "組み込み関数TODAYを実行")
+	return result
+}
+
+//formatTime FORMATTIME(time,layout) convert time to string
+func formatTime(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "FORMATTIME", "1または2")
+	}
+	t, ok := args[0].(*object.Time)
+	if !ok {
+		return errorwords.SetError(301, line, "FORMATTIME", "日時")
+	}
+	layout, err := timeLayoutArg("FORMATTIME", args, line)
+	if err != nil {
+		return err
+	}
+	result := formatLayout(t.Value, layout)
+	log.SetLog(line, "FORMATTIME("+t.Inspect()+")", `"`+result+`"`, "組み込み関数FORMATTIMEを実行")
+	return &object.String{Value: result, Line: line}
+}
+
+//parseTime PARSETIME(string,layout) convert string to time
+func parseTime(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "PARSETIME", "1または2")
+	}
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return errorwords.SetError(301, line, "PARSETIME", "文字列")
+	}
+	layouts := []string{defaultTimeLayout, "YYYY-MM-DD"}
+	if len(args) == 2 {
+		layout, err := timeLayoutArg("PARSETIME", args, line)
+		if err != nil {
+			return err
+		}
+		layouts = []string{layout}
+	}
+	for _, layout := range layouts {
+		if t, ok := parseLayout(str.Value, layout, clock().Location()); ok {
+			result := &object.Time{Value: t, Line: line}
+			log.SetLog(line, "PARSETIME("+str.Inspect()+")", result.Inspect(), "組み込み関数PARSETIMEを実行")
+			return result
+		}
+	}
+	return errorwords.SetError(318, line, str.Value, strings.Join(layouts, " または "))
+}
+
+//addDays ADDDAYS(time,days) return time after days
+func addDays(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "ADDDAYS", 2)
+	}
+	t, ok := args[0].(*object.Time)
+	if !ok {
+		return errorwords.SetError(301, line, "ADDDAYS", "日時")
+	}
+	days, ok := args[1].(*object.Int)
+	if !ok {
+		return errorwords.SetError(309, line, "ADDDAYS", 2, "整数")
+	}
+	result := &object.Time{Value: t.Value.AddDate(0, 0, int(days.Value)), Line: line}
+	log.SetLog(line, "ADDDAYS("+t.Inspect()+", "+days.Inspect()+")", result.Inspect(), "組み込み関数ADDDAYSを実行")
+	return result
+}
+
+//diffSeconds DIFFSECONDS(time1,time2) return seconds of time1 - time2
+func diffSeconds(line int, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return errorwords.SetError(300, line, "DIFFSECONDS", 2)
+	}
+	t1, ok := args[0].(*object.Time)
+	if !ok {
+		return errorwords.SetError(301, line, "DIFFSECONDS", "日時")
+	}
+	t2, ok := args[1].(*object.Time)
+	if !ok {
+		return errorwords.SetError(309, line, "DIFFSECONDS", 2, "日時")
+	}
+	result := &object.Int{Value: int64(t1.Value.Sub(t2.Value) / time.Second), Line: line}
+	log.SetLog(line, "DIFFSECONDS("+t1.Inspect()+", "+t2.Inspect()+")", result.Inspect(), "組み込み関数DIFFSECONDSを実行")
+	return result
+}
+
+//weekday WEEKDAY(time,japanese) return weekday name(Monday or 月曜日)
+func weekday(line int, args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return errorwords.SetError(300, line, "WEEKDAY", "1または2")
+	}
+	t, ok := args[0].(*object.Time)
+	if !ok {
+		return errorwords.SetError(301, line, "WEEKDAY", "日時")
+	}
+	japanese := false
+	if len(args) == 2 {
+		b, ok := args[1].(*object.Bool)
+		if !ok {
+			return errorwords.SetError(309, line, "WEEKDAY", 2, "真偽値")
+		}
+		japanese = b.Value
+	}
+	result := t.Value.Weekday().String()
+	if japanese {
+		result = jaWeekdays[t.Value.Weekday()]
+	}
+	log.SetLog(line, "WEEKDAY("+t.Inspect()+")", `"`+result+`"`, "組み込み関数WEEKDAYを実行")
+	return &object.String{Value: result, Line: line}
+}
+
+//timeLayoutArg get second argument(layout)
+func timeLayoutArg(name string, args []object.Object, line int) (string, object.Object) {
+	if len(args) != 2 {
+		return defaultTimeLayout, nil
+	}
+	layout, ok := args[1].(*object.String)
+	if !ok {
+		return "", errorwords.SetError(309, line, name, 2, "文字列(例:\"YYYY-MM-DD hh:mm:ss\")")
+	}
+	return layout.Value, nil
+}
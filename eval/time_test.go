@@ -0,0 +1,66 @@
+package eval
+
+import (
+	"github.com/hmwri/peridot/errorwords"
+	"github.com/hmwri/peridot/lexer"
+	"github.com/hmwri/peridot/object"
+	"github.com/hmwri/peridot/parser"
+	"testing"
+	"time"
+)
+
+//run evaluate source and return the result
+func run(t *testing.T, src string) object.Object {
+	t.Helper()
+	errorwords.Jerror()
+	p := parser.New(lexer.New(src))
+	program := p.Parse()
+	if errs := p.GetError(); len(errs) != 0 {
+		t.Fatalf("%q: parse error %v", src, errs[0].Message)
+	}
+	return Eval(program, object.NewEnv())
+}
+
+func TestTimeBuiltIns(t *testing.T) {
+	//2024-03-05 is Tuesday
+	SetClock(func() time.Time { return time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC) })
+	defer SetClock(nil)
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`FORMATTIME(NOW())`, `"2024-03-05 14:07:09"`},
+		{`FORMATTIME(TODAY())`, `"2024-03-05 00:00:00"`},
+		{`FORMATTIME(ADDDAYS(NOW(), 30), "YYYY/MM/DD")`, `"2024/04/04"`},
+		{`FORMATTIME(ADDDAYS(NOW(), -5), "YYYY/MM/DD")`, `"2024/02/29"`},
+		{`DIFFSECONDS(NOW(), TODAY())`, `50829`},
+		{`WEEKDAY(NOW())`, `"Tuesday"`},
+		{`WEEKDAY(NOW(), true)`, `"火曜日"`},
+		{`FORMATTIME(NOW(), "hh時mm分ss秒")`, `"14時07分09秒"`},
+		{`FORMATTIME(NOW(), "YYYY年MM月DD日 第1回 Monday Jan 2006")`, `"2024年03月05日 第1回 Monday Jan 2006"`},
+		{`FORMATTIME(PARSETIME("2024-12-31 23:59:58"))`, `"2024-12-31 23:59:58"`},
+		{`FORMATTIME(PARSETIME("2024-12-31"))`, `"2024-12-31 00:00:00"`},
+		{`FORMATTIME(PARSETIME("第1回 2024/03/05 Monday", "第1回 YYYY/MM/DD Monday"))`, `"2024-03-05 00:00:00"`},
+	}
+	for _, tt := range tests {
+		got := run(t, tt.src)
+		if got.Inspect() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.src, got.Inspect(), tt.want)
+		}
+	}
+}
+
+func TestParseTimeError(t *testing.T) {
+	tests := []string{
+		`PARSETIME("2024-02-30", "YYYY-MM-DD")`,
+		`PARSETIME("2024-3-05", "YYYY-MM-DD")`,
+		`PARSETIME("2024-03-05 10:00", "YYYY-MM-DD")`,
+		`PARSETIME("第2回 2024/03/05", "第1回 YYYY/MM/DD")`,
+	}
+	for _, src := range tests {
+		got, ok := run(t, src).(*object.ERROR)
+		if !ok || got.Code != 318 {
+			t.Errorf("%s should be error 318, got %v", src, got)
+		}
+	}
+}
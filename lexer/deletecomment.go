@@ -38,6 +38,10 @@ func DeleteComment(s string) string {
 		}
 		if o.ch == '<' && o.nextRead() == '<' && inflag == false {
 			for !(o.ch == '>' && o.nextRead() == '>') {
+				//keep newlines so that line numbers don't change
+				if o.ch == '\n' {
+					deleted += "\n"
+				}
 				o.readChar()
 				readnum++
 
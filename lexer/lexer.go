@@ -49,11 +49,16 @@ func (l *Lexer) NextToken() token.Token {
 		if next := l.nextRead(); next == '=' {
 			tok = token.Token{Type: token.Equal, Literal: "==", Line: l.line}
 			l.readChar()
+		} else if next == '>' {
+			tok = token.Token{Type: token.ARROW, Literal: "=>", Line: l.line}
+			l.readChar()
 		} else {
 			tok = newToken(token.ASSIGN, l.ch, l.line)
 		}
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch, l.line)
+	case ':':
+		tok = newToken(token.COLON, l.ch, l.line)
 	case '(':
 		tok = newToken(token.LPAREN, l.ch, l.line)
 	case ')':
@@ -72,16 +77,27 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.RBRACKET, l.ch, l.line)
 		l.autoSemicolon(tok.Type)
 	case '+':
-		tok = newToken(token.PLUS, l.ch, l.line)
+		tok = l.compoundToken(token.PLUS, token.PLUSASSIGN)
 	case '-':
-		tok = newToken(token.MINUS, l.ch, l.line)
+		tok = l.compoundToken(token.MINUS, token.MINUSASSIGN)
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch, l.line)
+		tok = l.compoundToken(token.ASTERISK, token.ASTERISKASSIGN)
 	case '/':
-
-		tok = newToken(token.SLASH, l.ch, l.line)
+		tok = l.compoundToken(token.SLASH, token.SLASHASSIGN)
 	case '%':
-		tok = newToken(token.PERCENT, l.ch, l.line)
+		tok = l.compoundToken(token.PERCENT, token.PERCENTASSIGN)
+	case '.':
+		if next := l.nextRead(); next == '.' {
+			l.readChar()
+			if l.nextRead() == '.' {
+				tok = token.Token{Type: token.ELLIPSIS, Literal: "...", Line: l.line}
+				l.readChar()
+			} else {
+				tok = token.Token{Type: token.DOTDOT, Literal: "..", Line: l.line}
+			}
+		} else {
+			tok = newToken(token.DOT, l.ch, l.line)
+		}
 	case '!':
 		if next := l.nextRead(); next == '=' {
 			tok = token.Token{Type: token.Nequal, Literal: "!=", Line: l.line}
@@ -129,6 +145,16 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
+//compoundToken If next character is '=',return compound assignment token(+= etc.)
+func (l *Lexer) compoundToken(op token.TokenType, assign token.TokenType) token.Token {
+	if l.nextRead() == '=' {
+		ch := l.ch
+		l.readChar()
+		return token.Token{Type: assign, Literal: string(ch) + "=", Line: l.line}
+	}
+	return newToken(op, l.ch, l.line)
+}
+
 //readLetter Return Letters and Tokentype
 func (l *Lexer) readLetter() token.Token {
 	start := l.position
@@ -144,7 +170,7 @@ func (l *Lexer) readLetter() token.Token {
 func (l *Lexer) readNumber() token.Token {
 	isFloat := false
 	start := l.position
-	for isNumber(l.ch) || (l.ch == '.' && !isFloat) {
+	for isNumber(l.ch) || (l.ch == '.' && !isFloat && l.nextRead() != '.') {
 		if l.ch == '.' {
 			isFloat = true
 		}
@@ -10,9 +10,11 @@ import (
 	"github.com/hmwri/peridot/object"
 	"github.com/hmwri/peridot/parser"
 	"github.com/hmwri/peridot/repl"
+	"github.com/hmwri/peridot/stdlib"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func main() {
@@ -44,8 +46,16 @@ func main() {
 	selectOption(arglen)
 
 }
+
+//longOptions long name of options(--exact is same as -e)
+var longOptions = map[string]byte{"--exact": 'e'}
+
 func selectOption(arglen int) {
-	switch os.Args[1][1] {
+	option := os.Args[1][1]
+	if long, found := longOptions[os.Args[1]]; found {
+		option = long
+	}
+	switch option {
 	case 'l':
 		if arglen == 2 {
 			fmt.Println("ファイル名を指定してください")
@@ -53,6 +63,28 @@ func selectOption(arglen int) {
 		}
 		env := object.NewEnv()
 		readMode(os.Args[2], env, true)
+	case 's':
+		if arglen == 2 {
+			fmt.Println("ファイル名を指定してください")
+			return
+		}
+		eval.LegacyScope = true
+		env := object.NewEnv()
+		readMode(os.Args[2], env, false)
+	case 'e':
+		if arglen == 2 {
+			fmt.Println("ファイル名を指定してください")
+			return
+		}
+		eval.Exact = true
+		env := object.NewEnv()
+		readMode(os.Args[2], env, false)
+	case 'd':
+		if arglen == 2 {
+			docList()
+			return
+		}
+		doc(os.Args[2])
 	case 'v':
 		fmt.Println("PeriDot " + info.Version + info.CheckVersion())
 	case 'h':
@@ -65,6 +97,9 @@ func selectOption(arglen int) {
 func options() {
 	fmt.Println("オプション一覧")
 	fmt.Println("[-l] ログ(実行過程)を表示")
+	fmt.Println("[-s] 古いスコープ(ブロック内のmakeが外でも使える)で実行")
+	fmt.Println("[-e, --exact] 分数モード(7 / 2 が 7/2 になる)で実行")
+	fmt.Println("[-d] 標準ライブラリ(またはファイル)の関数の説明を表示 例:pri -d list")
 	fmt.Println("[-v] バージョンを表示")
 	fmt.Println("[-h] ヘルプを表示")
 }
@@ -99,9 +134,11 @@ func readMode(t string, env *object.Env, logswitch bool) {
 		return
 	}
 	log.ResetLogs()
+	eval.SetMainFile(t)
 	l := lexer.New(string(w))
 	p := parser.New(l)
 	program := p.Parse()
+	Checkwarning(p)
 	if !Checkerror(p) {
 		eval := eval.Eval(program, env)
 		if logswitch {
@@ -112,7 +149,7 @@ func readMode(t string, env *object.Env, logswitch bool) {
 		if len(errorwords.Errp.Error) != 0 {
 			fmt.Printf("(´；ω；)<おっと!:%v\n", errorwords.Errp.Error[0].Message)
 		} else {
-			if eval != nil {
+			if eval != object.NULL {
 				fmt.Printf("(≧▽≦)Answer:")
 				fmt.Println(eval.Inspect())
 			}
@@ -131,3 +168,54 @@ func Checkerror(p *parser.Parser) bool {
 	}
 	return true
 }
+
+//docList print modules of standard library
+func docList() {
+	fmt.Println("標準ライブラリ(import \"名前\"で使えます)")
+	for _, name := range stdlib.Names() {
+		src, _ := stdlib.Source(name)
+		fmt.Printf("  %v: %v\n", name, stdlib.Summary(src))
+	}
+	fmt.Println("関数の説明を見るには pri -d 名前")
+}
+
+//doc print documents of functions in module of standard library or .pri file
+func doc(name string) {
+	src, found := stdlib.Source(name)
+	if !found {
+		if filepath.Ext(name) != ".pri" {
+			fmt.Println(name + "という標準ライブラリはありません")
+			docList()
+			return
+		}
+		w, err := os.ReadFile(name)
+		if err != nil {
+			fmt.Println("そのようなファイルはみつかりません")
+			return
+		}
+		src = string(w)
+	}
+	if summary := stdlib.Summary(src); summary != "" {
+		fmt.Println(summary)
+	}
+	for _, d := range stdlib.Docs(src) {
+		fmt.Printf("\nfunc %v(%v)\n", d.Name, d.Params)
+		for _, l := range strings.Split(d.Text, "\n") {
+			if l = strings.TrimSpace(l); l != "" {
+				fmt.Println("    " + l)
+			}
+		}
+	}
+}
+
+//Checkwarning print warnings(the program is run)
+func Checkwarning(p *parser.Parser) {
+	w := p.GetWarning()
+	if len(w) == 0 {
+		return
+	}
+	fmt.Printf("(・ω・)<注意が%v個あります\n", len(w))
+	for _, v := range w {
+		fmt.Printf("%v\n", v.Message)
+	}
+}
@@ -4,12 +4,16 @@ package object
 type Env struct {
 	envs map[string]Object
 	out  *Env
+	//consts names defined by const(can't be reassigned)
+	consts map[string]bool
+	//callee function running in this env(only env made by function call)
+	callee *Function
 }
 
 //NewEnv make new Env struct
 func NewEnv() *Env {
 	e := make(map[string]Object)
-	return &Env{envs: e}
+	return &Env{envs: e, consts: make(map[string]bool)}
 }
 
 //AddEnv Create new enclosed environment
@@ -19,6 +23,19 @@ func AddEnv(out *Env) *Env {
 	return in
 }
 
+//SetCallee Set function running in this env
+func (e *Env) SetCallee(fn *Function) {
+	e.callee = fn
+}
+
+//Callee Get function running in this env(nil if outside function)
+func (e *Env) Callee() *Function {
+	if e.callee == nil && e.out != nil {
+		return e.out.Callee()
+	}
+	return e.callee
+}
+
 //GetEnv Get env
 func (e *Env) GetEnv(name string) (Object, bool) {
 	obj, found := e.envs[name]
@@ -33,3 +50,37 @@ func (e *Env) SetEnv(name string, value Object) Object {
 	e.envs[name] = value
 	return value
 }
+
+//SetConst Set constant
+func (e *Env) SetConst(name string, value Object) Object {
+	e.consts[name] = true
+	return e.SetEnv(name, value)
+}
+
+//IsConst Check the nearest variable named name is constant
+func (e *Env) IsConst(name string) bool {
+	if _, found := e.envs[name]; found {
+		return e.consts[name]
+	}
+	if e.out != nil {
+		return e.out.IsConst(name)
+	}
+	return false
+}
+
+//IsLocalConst Check constant named name is defined in this env
+func (e *Env) IsLocalConst(name string) bool {
+	return e.consts[name]
+}
+
+//Assign Set value to defined variable in the env where it is defined(closure can change captured variable)
+func (e *Env) Assign(name string, value Object) bool {
+	if _, found := e.envs[name]; found {
+		e.envs[name] = value
+		return true
+	}
+	if e.out != nil {
+		return e.out.Assign(name, value)
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package object
+
+import (
+	"math"
+	"math/big"
+)
+
+//Equality object which can be compared by ==.Objects without it are equal only to themselves
+type Equality interface {
+	Equal(other Object) bool
+}
+
+//Equal check values are equal(arrays,maps and records are compared by contents,different types are not equal except numbers)
+func Equal(a, b Object) bool {
+	if eq, ok := a.(Equality); ok {
+		return eq.Equal(b)
+	}
+	return a == b
+}
+
+//CompareNumbers compare numbers(-1,0,1).If one is Float,compared as float64,else exactly.ok is false if not number or NaN
+func CompareNumbers(a, b Object) (int, bool) {
+	_, aFloat := a.(*Float)
+	_, bFloat := b.(*Float)
+	if aFloat || bFloat {
+		af, ok := ToFloat(a)
+		bf, ok2 := ToFloat(b)
+		if !ok || !ok2 || math.IsNaN(af) || math.IsNaN(bf) {
+			return 0, false
+		}
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	}
+	ar, ok := toRat(a)
+	br, ok2 := toRat(b)
+	if !ok || !ok2 {
+		return 0, false
+	}
+	return ar.Cmp(br), true
+}
+
+//toRat convert integer or fraction to big.Rat
+func toRat(o Object) (*big.Rat, bool) {
+	switch v := o.(type) {
+	case *Int:
+		return new(big.Rat).SetInt64(v.Value), true
+	case *BigInt:
+		return new(big.Rat).SetInt(v.Value), true
+	case *Rational:
+		return v.Value, true
+	}
+	return nil, false
+}
+
+//ToFloat convert number to float64.ok is false if not number
+func ToFloat(o Object) (float64, bool) {
+	switch v := o.(type) {
+	case *Float:
+		return v.Value, true
+	case *Int:
+		return float64(v.Value), true
+	case *BigInt:
+		f, _ := new(big.Float).SetInt(v.Value).Float64()
+		return f, true
+	case *Rational:
+		f, _ := v.Value.Float64()
+		return f, true
+	}
+	return 0, false
+}
+
+//numberEqual numbers are equal(1 == 1.0)
+func numberEqual(a, b Object) bool {
+	cmp, ok := CompareNumbers(a, b)
+	return ok && cmp == 0
+}
+
+//Equal Int equals number
+func (i *Int) Equal(other Object) bool { return numberEqual(i, other) }
+
+//Equal BigInt equals number
+func (b *BigInt) Equal(other Object) bool { return numberEqual(b, other) }
+
+//Equal Rational equals number
+func (r *Rational) Equal(other Object) bool { return numberEqual(r, other) }
+
+//Equal Float equals number
+func (f *Float) Equal(other Object) bool { return numberEqual(f, other) }
+
+//Equal Bool equals Bool
+func (b *Bool) Equal(other Object) bool {
+	o, ok := other.(*Bool)
+	return ok && b.Value == o.Value
+}
+
+//Equal String equals String
+func (s *String) Equal(other Object) bool {
+	o, ok := other.(*String)
+	return ok && s.Value == o.Value
+}
+
+//Equal nil equals only nil
+func (n *Null) Equal(other Object) bool {
+	_, ok := other.(*Null)
+	return ok
+}
+
+//Equal Array equals Array whose elements are all equal
+func (a *Array) Equal(other Object) bool {
+	o, ok := other.(*Array)
+	if !ok || len(a.Elements) != len(o.Elements) {
+		return false
+	}
+	for i, el := range a.Elements {
+		if !Equal(el, o.Elements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+//Equal Map equals Map which has same keys and equal values(order of keys is not compared)
+func (m *Map) Equal(other Object) bool {
+	o, ok := other.(*Map)
+	if !ok || len(m.Keys) != len(o.Keys) {
+		return false
+	}
+	for _, k := range m.Keys {
+		val, found := o.Pairs[k]
+		if !found || !Equal(m.Pairs[k], val) {
+			return false
+		}
+	}
+	return true
+}
+
+//Equal Record equals Record of same type whose fields are all equal
+func (r *Record) Equal(other Object) bool {
+	o, ok := other.(*Record)
+	if !ok || r.RecordType != o.RecordType {
+		return false
+	}
+	for name, val := range r.Fields {
+		if !Equal(val, o.Fields[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+//Equal ErrorValue equals ErrorValue which has same code and message
+func (e *ErrorValue) Equal(other Object) bool {
+	o, ok := other.(*ErrorValue)
+	return ok && e.Code == o.Code && e.Message == o.Message
+}
+
+//Equal Time equals same time
+func (t *Time) Equal(other Object) bool {
+	o, ok := other.(*Time)
+	return ok && t.Value.Equal(o.Value)
+}
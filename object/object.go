@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/hmwri/peridot/ast"
+	"math/big"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type (
@@ -16,24 +18,44 @@ type (
 const (
 	//IntOBJ > intenger object
 	IntOBJ = "INTENGER"
+	//BigIntOBJ > intenger object which doesn't fit in int64
+	BigIntOBJ = "BIGINTENGER"
+	//RationalOBJ > exact fraction object(exact mode)
+	RationalOBJ = "RATIONAL"
 	//FloatOBJ > FloatLiteral object
 	FloatOBJ = "FlOAT"
+	//NullOBJ > nil(no value) object
+	NullOBJ = "NULL"
 	//BoolOBJ > boolean object
 	BoolOBJ = "BOOLEAN"
 	//StringOBJ > StringLiteral object
 	StringOBJ = "STRING"
 	//ErrorOBJ > error object
 	ErrorOBJ = "ERROR"
+	//ErrorValueOBJ > caught error object
+	ErrorValueOBJ = "ERROR_VALUE"
 	//ReturnOBJ > return value object
 	ReturnOBJ = "RETURN_VALUE"
 	//StopOBJ > stop object
 	StopOBJ = "STOP"
+	//NextOBJ > next object
+	NextOBJ = "NEXT"
 	//FunctionOBJ > return function object
 	FunctionOBJ = "FUNCTION"
 	//BuiltInOBJ > built in function object
 	BuiltInOBJ = "BUILTIN"
 	//ArrayOBJ > built in function object
 	ArrayOBJ = "ARRAY"
+	//MapOBJ > keyed container object
+	MapOBJ = "MAP"
+	//TimeOBJ > date and time object
+	TimeOBJ = "TIME"
+	//RecordTypeOBJ > user defined type(type Point { x, y })
+	RecordTypeOBJ = "TYPE"
+	//RecordOBJ > value of user defined type
+	RecordOBJ = "RECORD"
+	//ModuleOBJ > imported module
+	ModuleOBJ = "MODULE"
 )
 
 //Object interface (Type(),Inspect(),GetVal(),GetLine())
@@ -62,6 +84,58 @@ func (i *Int) GetVal() interface{} { return i.Value }
 //GetLine Get Int Line (int)
 func (i *Int) GetLine() int { return i.Line }
 
+//BigInt object(intenger which doesn't fit in int64)
+type BigInt struct {
+	Value *big.Int
+	Line  int
+}
+
+//NewInteger make Int,or BigInt if value doesn't fit in int64
+func NewInteger(v *big.Int, line int) Object {
+	if v.IsInt64() {
+		return &Int{Value: v.Int64(), Line: line}
+	}
+	return &BigInt{Value: v, Line: line}
+}
+
+//Type Get BigInt type(ObjectType)
+func (b *BigInt) Type() ObjectType { return BigIntOBJ }
+
+//Inspect Get BigInt value(string)
+func (b *BigInt) Inspect() string { return b.Value.String() }
+
+//GetVal Get BigInt value(interface)
+func (b *BigInt) GetVal() interface{} { return b.Value }
+
+//GetLine Get BigInt Line(int)
+func (b *BigInt) GetLine() int { return b.Line }
+
+//Rational object(exact fraction like 7/2)
+type Rational struct {
+	Value *big.Rat
+	Line  int
+}
+
+//NewRational make Rational,or Int(BigInt) if denominator is 1
+func NewRational(v *big.Rat, line int) Object {
+	if v.IsInt() {
+		return NewInteger(new(big.Int).Set(v.Num()), line)
+	}
+	return &Rational{Value: v, Line: line}
+}
+
+//Type Get Rational type(ObjectType)
+func (r *Rational) Type() ObjectType { return RationalOBJ }
+
+//Inspect Get Rational value(string like 7/2)
+func (r *Rational) Inspect() string { return r.Value.RatString() }
+
+//GetVal Get Rational value(interface)
+func (r *Rational) GetVal() interface{} { return r.Value }
+
+//GetLine Get Rational Line(int)
+func (r *Rational) GetLine() int { return r.Line }
+
 //Float object
 type Float struct {
 	Value float64
@@ -80,6 +154,24 @@ func (f *Float) GetVal() interface{} { return f.Value }
 //GetLine Get Float Line (int)
 func (f *Float) GetLine() int { return f.Line }
 
+//Null object(nil).Statements and functions without value return NULL
+type Null struct{}
+
+//NULL the only Null object
+var NULL = &Null{}
+
+//Type Get Null type(ObjectType)
+func (n *Null) Type() ObjectType { return NullOBJ }
+
+//Inspect Get Null value(string)
+func (n *Null) Inspect() string { return "nil" }
+
+//GetVal Get Null value(interface)
+func (n *Null) GetVal() interface{} { return nil }
+
+//GetLine Get Null Line(int)
+func (n *Null) GetLine() int { return 0 }
+
 //Bool object
 type Bool struct {
 	Value bool
@@ -147,6 +239,7 @@ func (ar *Array) GetLine() int { return ar.Line }
 //ERROR object
 type ERROR struct {
 	Value string
+	Code  int
 	Line  int
 }
 
@@ -162,27 +255,68 @@ func (er *ERROR) GetVal() interface{} { return er.Value }
 //GetLine Get ERROR Line (int)
 func (er *ERROR) GetLine() int { return er.Line }
 
+//ErrorValue object(error caught by try)
+type ErrorValue struct {
+	Code    int
+	Message string
+	Line    int
+}
+
+//Inspect Get ErrorValue value(string)
+func (ev *ErrorValue) Inspect() string {
+	return fmt.Sprintf("エラー(コード:%d,%d行目):%s", ev.Code, ev.Line, ev.Message)
+}
+
+//Type Get ErrorValue type(ObjectType)
+func (ev *ErrorValue) Type() ObjectType { return ErrorValueOBJ }
+
+//GetVal Get ErrorValue value (interface)
+func (ev *ErrorValue) GetVal() interface{} { return ev.Message }
+
+//GetLine Get ErrorValue Line (int)
+func (ev *ErrorValue) GetLine() int { return ev.Line }
+
 //ReturnValue object
 type ReturnValue struct {
 	Value Object
 	Line  int
+	//TailCall If not nil,"return f(...)" calls the running function again(Value is nil)
+	TailCall *TailCall
+}
+
+//TailCall arguments of tail call(executed by loop in the caller instead of nested call)
+type TailCall struct {
+	Args  []Object
+	Named *Map
 }
 
 //Type Get ReturnValue type(ObjectType)
 func (rv *ReturnValue) Type() ObjectType { return ReturnOBJ }
 
 //Inspect Get ReturnValue value(string)
-func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
+func (rv *ReturnValue) Inspect() string {
+	if rv.Value == nil {
+		return "(末尾呼び出し)"
+	}
+	return rv.Value.Inspect()
+}
 
 //GetVal Get ReturnValue value(interface)
-func (rv *ReturnValue) GetVal() interface{} { return rv.Value.GetVal() }
+func (rv *ReturnValue) GetVal() interface{} {
+	if rv.Value == nil {
+		return nil
+	}
+	return rv.Value.GetVal()
+}
 
 //GetLine Get ReturnValue Line(int)
 func (rv *ReturnValue) GetLine() int { return rv.Line }
 
 //Stop object
 type Stop struct {
-	Line int
+	//Label Label of loop to leave(empty is innermost loop)
+	Label string
+	Line  int
 }
 
 //Type Get Stop type(ObjectType)
@@ -197,13 +331,37 @@ func (sp *Stop) GetVal() interface{} { return "stop" }
 //GetLine Get Stop Line(int)
 func (sp *Stop) GetLine() int { return sp.Line }
 
+//Next object
+type Next struct {
+	//Label Label of loop to continue(empty is innermost loop)
+	Label string
+	Line  int
+}
+
+//Type Get Next type(ObjectType)
+func (nx *Next) Type() ObjectType { return NextOBJ }
+
+//Inspect Get Next value(string)
+func (nx *Next) Inspect() string { return "next" }
+
+//GetVal Get Next value(interface)
+func (nx *Next) GetVal() interface{} { return "next" }
+
+//GetLine Get Next Line(int)
+func (nx *Next) GetLine() int { return nx.Line }
+
 //Function object
 type Function struct {
-	Name    *ast.Identifier
-	Params  []*ast.Identifier
-	Process *ast.BlockStmt
-	Env     *Env
-	Line    int
+	Name     *ast.Identifier
+	Params   []*ast.Identifier
+	Defaults []ast.Expression
+	Rest     *ast.Identifier
+	//Receiver,RecvType receiver of method(func (p Point) name() {})
+	Receiver *ast.Identifier
+	RecvType *ast.Identifier
+	Process  *ast.BlockStmt
+	Env      *Env
+	Line     int
 }
 
 //Type Get Function type(ObjectType)
@@ -212,15 +370,15 @@ func (fn *Function) Type() ObjectType { return FunctionOBJ }
 //Inspect Get Function value(string)
 func (fn *Function) Inspect() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range fn.Params {
-		params = append(params, p.String())
-	}
+	params := ast.ParamStrings(fn.Params, fn.Defaults, fn.Rest)
 	name := ""
 	if fn.Name != nil {
 		name = fn.Name.String()
 	}
 	out.WriteString("\nfunc")
+	if fn.Receiver != nil {
+		out.WriteString(" (" + fn.Receiver.String() + " " + fn.RecvType.String() + ")")
+	}
 	out.WriteString(" " + name + " ")
 	out.WriteString("( ")
 	out.WriteString(strings.Join(params, ","))
@@ -253,3 +411,150 @@ func (b *BuiltIn) GetVal() interface{} { return "This is BuiltInFunction" }
 
 //GetLine Get BuiltIn Line(int)
 func (b *BuiltIn) GetLine() int { return 0 }
+
+//Map object(keyed container)
+type Map struct {
+	//Keys Keys in insertion order
+	Keys  []string
+	Pairs map[string]Object
+	Line  int
+}
+
+//NewMap make empty Map
+func NewMap(line int) *Map {
+	return &Map{Keys: []string{}, Pairs: make(map[string]Object), Line: line}
+}
+
+//Set Set value to key(keeps insertion order)
+func (m *Map) Set(key string, value Object) {
+	if _, found := m.Pairs[key]; !found {
+		m.Keys = append(m.Keys, key)
+	}
+	m.Pairs[key] = value
+}
+
+//Get Get value of key
+func (m *Map) Get(key string) (Object, bool) {
+	obj, found := m.Pairs[key]
+	return obj, found
+}
+
+//Type Get Map type(ObjectType)
+func (m *Map) Type() ObjectType { return MapOBJ }
+
+//Inspect Get Map value(string)
+func (m *Map) Inspect() string {
+	var out bytes.Buffer
+	pairs := []string{}
+	for _, k := range m.Keys {
+		pairs = append(pairs, fmt.Sprintf(`"%v": %v`, k, m.Pairs[k].Inspect()))
+	}
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+//GetVal Get Map value(interface)
+func (m *Map) GetVal() interface{} { return m.Pairs }
+
+//GetLine Get Map Line(int)
+func (m *Map) GetLine() int { return m.Line }
+
+//Time object(date and time)
+type Time struct {
+	Value time.Time
+	Line  int
+}
+
+//Type Get Time type(ObjectType)
+func (t *Time) Type() ObjectType { return TimeOBJ }
+
+//Inspect Get Time value(string)
+func (t *Time) Inspect() string { return t.Value.Format("2006-01-02 15:04:05") }
+
+//GetVal Get Time value(interface)
+func (t *Time) GetVal() interface{} { return t.Value }
+
+//GetLine Get Time Line(int)
+func (t *Time) GetLine() int { return t.Line }
+
+//RecordType object(user defined type)
+type RecordType struct {
+	Name    string
+	Fields  []string
+	Methods map[string]*Function
+	Line    int
+}
+
+//HasField Check type has field
+func (rt *RecordType) HasField(name string) bool {
+	for _, f := range rt.Fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+//Type Get RecordType type(ObjectType)
+func (rt *RecordType) Type() ObjectType { return RecordTypeOBJ }
+
+//Inspect Get RecordType value(string)
+func (rt *RecordType) Inspect() string {
+	return "type " + rt.Name + " { " + strings.Join(rt.Fields, ", ") + " }"
+}
+
+//GetVal Get RecordType value(interface)
+func (rt *RecordType) GetVal() interface{} { return rt.Name }
+
+//GetLine Get RecordType Line(int)
+func (rt *RecordType) GetLine() int { return rt.Line }
+
+//Record object(value of user defined type)
+type Record struct {
+	RecordType *RecordType
+	Fields     map[string]Object
+	Line       int
+}
+
+//Type Get Record type(ObjectType)
+func (r *Record) Type() ObjectType { return RecordOBJ }
+
+//Inspect Get Record value(string)
+func (r *Record) Inspect() string {
+	var out bytes.Buffer
+	fields := []string{}
+	for _, f := range r.RecordType.Fields {
+		fields = append(fields, f+": "+r.Fields[f].Inspect())
+	}
+	out.WriteString(r.RecordType.Name + "{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+//GetVal Get Record value(interface)
+func (r *Record) GetVal() interface{} { return r.Fields }
+
+//GetLine Get Record Line(int)
+func (r *Record) GetLine() int { return r.Line }
+
+//Module object(imported file)
+type Module struct {
+	Name string
+	Path string
+	Env  *Env
+}
+
+//Type Get Module type(ObjectType)
+func (m *Module) Type() ObjectType { return ModuleOBJ }
+
+//Inspect Get Module value(string)
+func (m *Module) Inspect() string { return "module " + m.Name + "(" + m.Path + ")" }
+
+//GetVal Get Module value(interface)
+func (m *Module) GetVal() interface{} { return m.Path }
+
+//GetLine Get Module Line(int)
+func (m *Module) GetLine() int { return 0 }
@@ -6,6 +6,8 @@ import (
 	"github.com/hmwri/peridot/errorwords"
 	"github.com/hmwri/peridot/lexer"
 	"github.com/hmwri/peridot/token"
+	"math/big"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -21,6 +23,14 @@ type (
 		readToken token.Token
 		//erros
 		errors []Err
+		//loops Labels of loops now parsing(no label is "")
+		loops []string
+		//scopes Names defined in each block(last is now scope)
+		scopes []*scope
+		//warnings
+		warnings []Err
+		//tailExpr If true,expression now parsing is statement in tail position(if,match read it)
+		tailExpr bool
 		//fixparsefunctions map
 		prefixParseFns map[token.TokenType]prefixParseFn
 		infixParseFns  map[token.TokenType]infixParseFn
@@ -44,6 +54,7 @@ const (
 	AND                 //and
 	EQUAL               //==
 	INEQUAL             //<,>
+	RANGE               //..
 	ADDSUB              //+,-
 	MULTIDIV            //*,/
 	PREFIX              //-x !x
@@ -61,6 +72,7 @@ var priority = map[token.TokenType]int{
 	token.LT:       INEQUAL,
 	token.ERT:      INEQUAL,
 	token.ELT:      INEQUAL,
+	token.DOTDOT:   RANGE,
 	token.PLUS:     ADDSUB,
 	token.MINUS:    ADDSUB,
 	token.ASTERISK: MULTIDIV,
@@ -68,22 +80,35 @@ var priority = map[token.TokenType]int{
 	token.PERCENT:  MULTIDIV,
 	token.LPAREN:   FUNC,
 	token.LBRACKET: INDEX,
+	token.DOT:      INDEX,
+}
+
+//compound assignment operators(+= is +)
+var compoundOperators = map[token.TokenType]string{
+	token.PLUSASSIGN:     "+",
+	token.MINUSASSIGN:    "-",
+	token.ASTERISKASSIGN: "*",
+	token.SLASHASSIGN:    "/",
+	token.PERCENTASSIGN:  "%",
 }
 
 //New Make Parser struct and call nextToken(Parser format)
 func New(l *lexer.Lexer) *Parser {
 	errorwords.Jerror()
-	p := &Parser{l: l, errors: []Err{}}
+	p := &Parser{l: l, errors: []Err{}, scopes: []*scope{newScope(false)}}
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.addPrefix(token.IF, p.parseIf)
 	p.addPrefix(token.FUNCTION, p.parseFunction)
+	p.addPrefix(token.MATCH, p.parseMatch)
+	p.addPrefix(token.TRY, p.parseTry)
 	//values
 	p.addPrefix(token.IDENT, p.parseIdent)
 	p.addPrefix(token.INT, p.parseInt)
 	p.addPrefix(token.FLOAT, p.parseFloat)
 	p.addPrefix(token.TRUE, p.parseBool)
 	p.addPrefix(token.FALSE, p.parseBool)
+	p.addPrefix(token.NIL, p.parseNil)
 	p.addPrefix(token.STRING, p.parseString)
 	//
 	p.addPrefix(token.LPAREN, p.parsegroup)
@@ -105,9 +130,11 @@ func New(l *lexer.Lexer) *Parser {
 	p.addInfix(token.ASTERISK, p.parseInfix)
 	p.addInfix(token.SLASH, p.parseInfix)
 	p.addInfix(token.PERCENT, p.parseInfix)
+	p.addInfix(token.DOTDOT, p.parseRange)
 	//
 	p.addInfix(token.LPAREN, p.parseCall)
 	p.addInfix(token.LBRACKET, p.parseIndex)
+	p.addInfix(token.DOT, p.parseField)
 	//
 	p.nextToken()
 	p.nextToken()
@@ -139,12 +166,20 @@ func (p *Parser) parseStmt() ast.Statement {
 	switch p.nowToken.Type {
 	case token.MAKE:
 		return p.parseMakeStmt()
+	case token.CONST:
+		return p.parseConstStmt()
+	case token.TYPE:
+		return p.parseTypeDecl()
+	case token.IMPORT:
+		return p.parseImport()
 	case token.RETURN:
 		return p.parseReturnStmt()
 	case token.LOOP:
 		return p.parseLoop()
 	case token.STOP:
 		return p.parseStopStmt()
+	case token.NEXT:
+		return p.parseNextStmt()
 	default:
 		return p.parseExprStmt()
 	}
@@ -160,6 +195,8 @@ func (p *Parser) parseMakeStmt() *ast.Make {
 	}
 
 	makestmt.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	p.checkConst(makestmt.Name)
+	p.declare(makestmt.Name)
 
 	if !p.expect(token.ASSIGN) {
 
@@ -173,9 +210,89 @@ func (p *Parser) parseMakeStmt() *ast.Make {
 	return makestmt
 }
 
+//parseConstStmt return parsed statement and check statement
+func (p *Parser) parseConstStmt() ast.Statement {
+	tok := p.nowToken
+	makestmt := p.parseMakeStmt()
+	if makestmt == nil {
+		return nil
+	}
+	p.nowScope().consts[makestmt.Name.Value] = true
+	return &ast.Const{Token: tok, Name: makestmt.Name, Value: makestmt.Value}
+}
+
+//parseTypeDecl return parsed statement(type Point { x, y })
+func (p *Parser) parseTypeDecl() ast.Statement {
+	td := &ast.TypeDecl{Token: p.nowToken}
+	if !p.expect(token.IDENT) {
+		return nil
+	}
+	td.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	p.checkConst(td.Name)
+	p.declare(td.Name)
+	if !p.expect(token.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+	//fields are separated by comma or newline
+	for !p.nowTokenType(token.RBRACE) {
+		switch p.nowToken.Type {
+		case token.IDENT:
+			field := &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+			for _, f := range td.Fields {
+				if f.Value == field.Value {
+					p.setError(154, field.Token.Line, field.Value)
+				}
+			}
+			td.Fields = append(td.Fields, field)
+		case token.COMMA, token.SEMICOLON:
+		default:
+			p.setError(101, p.nowToken.Line, token.IDENT, p.nowToken.Literal)
+			return nil
+		}
+		p.nextToken()
+	}
+	if p.readToken.Type == token.SEMICOLON {
+		p.nextToken()
+	}
+	return td
+}
+
+//parseImport return parsed statement(import "utils.pri" as u)
+func (p *Parser) parseImport() ast.Statement {
+	im := &ast.Import{Token: p.nowToken}
+	if !p.expect(token.STRING) {
+		return nil
+	}
+	im.Path = p.nowToken.Literal
+	if p.nextTokenType(token.AS) {
+		p.nextToken()
+		if !p.expect(token.IDENT) {
+			return nil
+		}
+		im.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+		im.Alias = true
+	} else {
+		base := filepath.Base(im.Path)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+		im.Name = &ast.Identifier{Token: token.Token{Type: token.IDENT, Literal: name, Line: im.Token.Line}, Value: name}
+		//file name like my-utils can't be used as name
+		if tok := lexer.New(name).NextToken(); tok.Type != token.IDENT || tok.Literal != name {
+			p.setError(155, im.Token.Line, name, im.Path)
+			return nil
+		}
+	}
+	p.checkConst(im.Name)
+	p.declare(im.Name)
+	if p.readToken.Type == token.SEMICOLON {
+		p.nextToken()
+	}
+	return im
+}
+
 //parseRetutnStmt return  parsed statement and check statement
 func (p *Parser) parseReturnStmt() *ast.Return {
-	returnstmt := &ast.Return{Token: p.nowToken}
+	returnstmt := &ast.Return{Token: p.nowToken, Tail: p.nowScope().tail}
 	p.nextToken()
 	returnstmt.Value = p.parseExpression(LOWEST)
 	if p.readToken.Type == token.SEMICOLON {
@@ -187,15 +304,51 @@ func (p *Parser) parseReturnStmt() *ast.Return {
 //parseStopStmt return  parsed statement and check statement
 func (p *Parser) parseStopStmt() *ast.Stop {
 	stopstmt := &ast.Stop{Token: p.nowToken}
+	stopstmt.Label = p.parseLoopLabel()
 	if p.readToken.Type == token.SEMICOLON {
 		p.nextToken()
 	}
 	return stopstmt
 }
 
+//parseNextStmt return  parsed statement and check statement
+func (p *Parser) parseNextStmt() *ast.Next {
+	nextstmt := &ast.Next{Token: p.nowToken}
+	nextstmt.Label = p.parseLoopLabel()
+	if p.readToken.Type == token.SEMICOLON {
+		p.nextToken()
+	}
+	return nextstmt
+}
+
+//parseLoopLabel parse label after stop,next and check they are in loop
+func (p *Parser) parseLoopLabel() *ast.Identifier {
+	tok := p.nowToken
+	var label *ast.Identifier
+	if p.nextTokenType(token.IDENT) {
+		p.nextToken()
+		label = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	}
+	if len(p.loops) == 0 {
+		p.setError(140, tok.Line, tok.Literal)
+		return label
+	}
+	if label != nil {
+		for _, l := range p.loops {
+			if l == label.Value {
+				return label
+			}
+		}
+		p.setError(141, tok.Line, label.Value, label.Value)
+	}
+	return label
+}
+
 //parse expressionstatement
 func (p *Parser) parseExprStmt() *ast.ExpressionStatement {
 	stmt := &ast.ExpressionStatement{Token: p.nowToken}
+	//if,match which is statement itself keeps tail position
+	p.tailExpr = p.nowScope().tail
 	stmt.Expression = p.parseExpression(LOWEST)
 	if p.readToken.Type == token.SEMICOLON {
 		p.nextToken()
@@ -217,6 +370,7 @@ func (p *Parser) parseExpression(level int) ast.Expression {
 	}
 	//call prefix parse function and put it into left
 	left := prefn()
+	p.tailExpr = false
 	for level < p.nextTokenPriority() {
 		infn := p.infixParseFns[p.readToken.Type]
 		if infn == nil {
@@ -231,45 +385,194 @@ func (p *Parser) parseExpression(level int) ast.Expression {
 //parseIfExpression return parsed expression and check exoression
 func (p *Parser) parseIf() ast.Expression {
 	ifexp := &ast.If{Token: p.nowToken}
+	tail := p.tailExpr
+	p.tailExpr = false
 	p.nextToken()
 	ifexp.Condition = p.parseExpression(LOWEST)
 	if !p.expect(token.LBRACE) {
 		return nil
 	}
-	ifexp.Consequence = p.parseBlockstmt()
+	ifexp.Consequence = p.parseBranchBlock(tail)
 
 	if p.nextTokenType(token.ELSE) {
 		p.nextToken()
+		//else if chain is nested If
 		if p.nextTokenType(token.IF) {
-			ifexp.Alternative = p.parseBlockstmt()
+			p.nextToken()
+			p.tailExpr = tail
+			elseif, ok := p.parseIf().(*ast.If)
+			if !ok {
+				return nil
+			}
+			ifexp.ElseIf = elseif
 			return ifexp
 		}
 		if !p.expect(token.LBRACE) {
 			return nil
 		}
-		ifexp.Alternative = p.parseBlockstmt()
+		ifexp.Alternative = p.parseBranchBlock(tail)
 	}
 	return ifexp
 }
 
+//parseTry return parsed expression(try {} catch e {})
+func (p *Parser) parseTry() ast.Expression {
+	tr := &ast.Try{Token: p.nowToken}
+	if !p.expect(token.LBRACE) {
+		return nil
+	}
+	tr.Block = p.parseBlockstmt()
+	if !p.expect(token.CATCH) {
+		return nil
+	}
+	if p.nextTokenType(token.IDENT) {
+		p.nextToken()
+		tr.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	}
+	if !p.expect(token.LBRACE) {
+		return nil
+	}
+	tr.Handler = p.parseBlockstmt(tr.Name)
+	return tr
+}
+
+//parseMatch return parsed expression(match value { pattern => value })
+func (p *Parser) parseMatch() ast.Expression {
+	mt := &ast.Match{Token: p.nowToken}
+	tail := p.tailExpr
+	p.tailExpr = false
+	p.nextToken()
+	mt.Value = p.parseExpression(LOWEST)
+	if !p.expect(token.LBRACE) {
+		return nil
+	}
+	p.nextToken()
+	for !p.nowTokenType(token.RBRACE) {
+		if p.nowTokenType(token.EOF) {
+			p.setError(101, p.nowToken.Line, token.RBRACE, p.nowToken.Literal)
+			return nil
+		}
+		//arms are separated by , or new line
+		if p.nowTokenType(token.COMMA) || p.nowTokenType(token.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+		arm := p.parseMatchArm(tail)
+		if arm == nil {
+			return nil
+		}
+		mt.Arms = append(mt.Arms, arm)
+		p.nextToken()
+	}
+	return mt
+}
+
+//parseMatchArm return parsed arm of match(1, 2 if guard => body)
+func (p *Parser) parseMatchArm(tail bool) *ast.MatchArm {
+	arm := &ast.MatchArm{Token: p.nowToken}
+	arm.Patterns = append(arm.Patterns, p.parseExpression(LOWEST))
+	for p.nextTokenType(token.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		arm.Patterns = append(arm.Patterns, p.parseExpression(LOWEST))
+	}
+	if p.nextTokenType(token.IF) {
+		p.nextToken()
+		p.nextToken()
+		arm.Guard = p.parseExpression(LOWEST)
+	}
+	if !p.expect(token.ARROW) {
+		return nil
+	}
+	if p.nextTokenType(token.LBRACE) {
+		p.nextToken()
+		arm.Body = p.parseBranchBlock(tail)
+		return arm
+	}
+	p.nextToken()
+	tok := p.nowToken
+	body := &ast.ExpressionStatement{Token: tok, Expression: p.parseExpression(LOWEST)}
+	arm.Body = &ast.BlockStmt{Token: tok, Statements: []ast.Statement{body}}
+	return arm
+}
+
 //parseLoop Statement
 func (p *Parser) parseLoop() ast.Statement {
 	lpexp := &ast.Loop{Token: p.nowToken}
+	lpexp.Condition = p.parseLoopCondition()
+	//loop label: ...
+	if ident, ok := lpexp.Condition.(*ast.Identifier); ok && p.nextTokenType(token.COLON) {
+		p.nextToken()
+		lpexp.Label = ident
+		lpexp.Condition = p.parseLoopCondition()
+	}
+	//loop x in array / loop i, x in array
+	if ident, ok := lpexp.Condition.(*ast.Identifier); ok && (p.nextTokenType(token.IN) || p.nextTokenType(token.COMMA)) {
+		return p.parseForEach(lpexp.Token, lpexp.Label, ident)
+	}
+	if !p.expect(token.LBRACE) {
+		return nil
+	}
+	lpexp.Process = p.parseLoopBlock(lpexp.Label)
+	if p.readToken.Type == token.SEMICOLON {
+		p.nextToken()
+	}
+	return lpexp
+}
+
+//parseLoopCondition parse condition after loop(no condition is true)
+func (p *Parser) parseLoopCondition() ast.Expression {
 	if p.nextTokenType(token.LBRACE) {
 		truetoken := token.Token{Type: token.TRUE, Literal: "true", Line: p.nowToken.Line}
-		lpexp.Condition = &ast.Bool{Token: truetoken, Value: true}
-	} else {
+		return &ast.Bool{Token: truetoken, Value: true}
+	}
+	p.nextToken()
+	//label(loop name:) and loop variables(loop x in) are declarations,not use of variables
+	if p.nowTokenType(token.IDENT) && (p.nextTokenType(token.COLON) || p.nextTokenType(token.IN) || p.nextTokenType(token.COMMA)) {
+		return &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	}
+	return p.parseExpression(LOWEST)
+}
+
+//parseLoopBlock parse block of loop(stop,next can be used in it)
+func (p *Parser) parseLoopBlock(label *ast.Identifier, names ...*ast.Identifier) *ast.BlockStmt {
+	name := ""
+	if label != nil {
+		name = label.Value
+	}
+	p.loops = append(p.loops, name)
+	//loop is statement,so body keeps tail position of outer block
+	s := newScope(false, names...)
+	s.tail = p.nowScope().tail
+	bs := p.parseScopedBlock(s)
+	p.loops = p.loops[:len(p.loops)-1]
+	return bs
+}
+
+//parseForEach Statement(first is first loop variable)
+func (p *Parser) parseForEach(tok token.Token, label *ast.Identifier, first *ast.Identifier) ast.Statement {
+	fe := &ast.ForEach{Token: tok, Label: label, Value: first}
+	if p.nextTokenType(token.COMMA) {
 		p.nextToken()
-		lpexp.Condition = p.parseExpression(LOWEST)
+		if !p.expect(token.IDENT) {
+			return nil
+		}
+		fe.Index = first
+		fe.Value = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
 	}
+	if !p.expect(token.IN) {
+		return nil
+	}
+	p.nextToken()
+	fe.Iterable = p.parseExpression(LOWEST)
 	if !p.expect(token.LBRACE) {
 		return nil
 	}
-	lpexp.Process = p.parseBlockstmt()
+	fe.Process = p.parseLoopBlock(fe.Label, fe.Index, fe.Value)
 	if p.readToken.Type == token.SEMICOLON {
 		p.nextToken()
 	}
-	return lpexp
+	return fe
 }
 
 //parseFunction return parsed expression and check expression
@@ -278,48 +581,153 @@ func (p *Parser) parseFunction() ast.Expression {
 	if p.nextTokenType(token.IDENT) {
 		p.nextToken()
 		fnexp.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+		p.checkConst(fnexp.Name)
+		p.declare(fnexp.Name)
 	}
 	if !p.expect(token.LPAREN) {
 		return nil
 	}
-	fnexp.Parameters = p.parseFnParams()
+	if fnexp.Name == nil && p.nextTokenType(token.IDENT) {
+		p.nextToken()
+		//func (p Point) name() {} is method
+		if p.nextTokenType(token.IDENT) {
+			if !p.parseReceiver(fnexp) || !p.parseFnParams(fnexp) {
+				return nil
+			}
+		} else if !p.parseFnParamList(fnexp) {
+			return nil
+		}
+	} else if !p.parseFnParams(fnexp) {
+		return nil
+	}
 	if !p.expect(token.LBRACE) {
 		return nil
 	}
-	fnexp.Process = p.parseBlockstmt()
+	//stop,next in function can't leave loops outside function
+	loops := p.loops
+	p.loops = nil
+	fnexp.Process = p.parseScopedBlock(newScope(true, append(fnexp.Parameters, fnexp.Rest, fnexp.Receiver)...))
+	p.loops = loops
 
 	return fnexp
 }
 
-//parse Function Parameters
-func (p *Parser) parseFnParams() []*ast.Identifier {
-	idents := []*ast.Identifier{}
+//parse Function Parameters(name, name = default, ...rest)
+func (p *Parser) parseFnParams(fn *ast.Function) bool {
+	fn.Parameters = []*ast.Identifier{}
 	if p.nextTokenType(token.RPAREN) {
 		p.nextToken()
-		return idents
+		return true
 	}
 	p.nextToken()
-	ident := &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
-	idents = append(idents, ident)
+	return p.parseFnParamList(fn)
+}
+
+//parseFnParamList parse parameters from now token to ')'
+func (p *Parser) parseFnParamList(fn *ast.Function) bool {
+	if !p.parseFnParam(fn) {
+		return false
+	}
 	for p.nextTokenType(token.COMMA) {
 		p.nextToken()
 		p.nextToken()
-		ident := &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
-		idents = append(idents, ident)
+		if !p.parseFnParam(fn) {
+			return false
+		}
 	}
-	if !p.expect(token.RPAREN) {
-		return nil
+	return p.expect(token.RPAREN)
+}
+
+//parseReceiver parse receiver and name of method(now token is receiver name)
+func (p *Parser) parseReceiver(fn *ast.Function) bool {
+	fn.Receiver = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	p.nextToken()
+	fn.RecvType = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	if !p.expect(token.RPAREN) || !p.expect(token.IDENT) {
+		return false
+	}
+	fn.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	return p.expect(token.LPAREN)
+}
+
+//parseFnParam parse a parameter at now token and add it to fn
+func (p *Parser) parseFnParam(fn *ast.Function) bool {
+	if fn.Rest != nil {
+		p.setError(151, fn.Rest.Token.Line, fn.Rest.Value)
+		return false
+	}
+	rest := p.nowTokenType(token.ELLIPSIS)
+	if rest {
+		p.nextToken()
+	}
+	if !p.nowTokenType(token.IDENT) {
+		p.setError(101, p.nowToken.Line, token.IDENT, p.nowToken.Literal)
+		return false
+	}
+	ident := &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	for _, param := range fn.Parameters {
+		if param.Value == ident.Value {
+			p.setError(150, ident.Token.Line, ident.Value)
+			return false
+		}
+	}
+	if rest {
+		fn.Rest = ident
+		return true
+	}
+	var def ast.Expression
+	if p.nextTokenType(token.ASSIGN) {
+		p.nextToken()
+		p.nextToken()
+		def = p.parseExpression(LOWEST)
+	} else if len(fn.Defaults) > 0 && fn.Defaults[len(fn.Defaults)-1] != nil {
+		p.setError(152, ident.Token.Line, ident.Value)
+		return false
 	}
-	return idents
+	fn.Parameters = append(fn.Parameters, ident)
+	fn.Defaults = append(fn.Defaults, def)
+	return true
 }
 
 //parseCall return parsed expression and check expression
 func (p *Parser) parseCall(function ast.Expression) ast.Expression {
-	callexp := &ast.Call{Token: p.nowToken, Function: function}
-	callexp.Arguments = p.parseList(token.RPAREN)
+	callexp := &ast.Call{Token: p.nowToken, Function: function, Arguments: []ast.Expression{}}
+	if p.nextTokenType(token.RPAREN) {
+		p.nextToken()
+		return callexp
+	}
+	p.nextToken()
+	p.parseCallArg(callexp)
+	for p.nextTokenType(token.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		p.parseCallArg(callexp)
+	}
+	p.expect(token.RPAREN)
 	return callexp
 }
 
+//parseCallArg parse one argument of call.name = value is named argument(not assignment)
+func (p *Parser) parseCallArg(callexp *ast.Call) {
+	if p.nowTokenType(token.IDENT) && p.nextTokenType(token.ASSIGN) {
+		na := &ast.NamedArg{Token: p.nowToken, Name: &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}}
+		for _, other := range callexp.Named {
+			if other.Name.Value == na.Name.Value {
+				p.setError(150, na.Token.Line, na.Name.Value)
+			}
+		}
+		p.nextToken()
+		p.nextToken()
+		na.Value = p.parseExpression(LOWEST)
+		callexp.Named = append(callexp.Named, na)
+		return
+	}
+	if len(callexp.Named) > 0 {
+		p.setError(153, callexp.Token.Line)
+	}
+	callexp.Arguments = append(callexp.Arguments, p.parseExpression(LOWEST))
+}
+
 //parseArray return parsed expression and check expression
 func (p *Parser) parseArray() ast.Expression {
 	ar := &ast.Array{Token: p.nowToken}
@@ -355,13 +763,60 @@ func (p *Parser) parseIndex(left ast.Expression) ast.Expression {
 	if !p.expect(token.RBRACKET) {
 		return nil
 	}
+	//arr[i] = value
+	operator, compound := compoundOperators[p.readToken.Type]
+	if p.nextTokenType(token.ASSIGN) || compound {
+		p.nextToken()
+		tok := p.nowToken
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+		if p.readToken.Type == token.SEMICOLON {
+			p.nextToken()
+		}
+		return &ast.IndexAssign{Token: tok, Target: ix, Operator: operator, Value: value}
+	}
 	return ix
 }
 
-//parse Block Statements
-func (p *Parser) parseBlockstmt() *ast.BlockStmt {
+//parseField return parsed expression(p.x,p.x = value)
+func (p *Parser) parseField(left ast.Expression) ast.Expression {
+	fd := &ast.Field{Token: p.nowToken, Left: left}
+	if !p.expect(token.IDENT) {
+		return nil
+	}
+	fd.Name = &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	//p.x = value
+	operator, compound := compoundOperators[p.readToken.Type]
+	if p.nextTokenType(token.ASSIGN) || compound {
+		p.nextToken()
+		tok := p.nowToken
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+		if p.readToken.Type == token.SEMICOLON {
+			p.nextToken()
+		}
+		return &ast.FieldAssign{Token: tok, Target: fd, Operator: operator, Value: value}
+	}
+	return fd
+}
+
+//parse Block Statements(names are defined in the block)
+func (p *Parser) parseBlockstmt(names ...*ast.Identifier) *ast.BlockStmt {
+	return p.parseScopedBlock(newScope(false, names...))
+}
+
+//parseBranchBlock parse block of if,else,match arm(tail is true if the if,match is in tail position)
+func (p *Parser) parseBranchBlock(tail bool) *ast.BlockStmt {
+	s := newScope(false)
+	s.tail = tail
+	return p.parseScopedBlock(s)
+}
+
+//parse Block Statements in scope s
+func (p *Parser) parseScopedBlock(s *scope) *ast.BlockStmt {
 	var bs *ast.BlockStmt
 	bs = &ast.BlockStmt{Token: p.nowToken, Statements: []ast.Statement{}}
+	p.openScope(s)
 	p.nextToken()
 	for !p.nowTokenType(token.RBRACE) && !p.nowTokenType(token.EOF) {
 		stmt := p.parseStmt()
@@ -370,6 +825,7 @@ func (p *Parser) parseBlockstmt() *ast.BlockStmt {
 		}
 		p.nextToken()
 	}
+	p.closeScope()
 	return bs
 }
 
@@ -378,24 +834,33 @@ func (p *Parser) parseIdent() ast.Expression {
 	if strings.Index(p.nowToken.Literal, "　") != -1 {
 		p.setError(130, p.nowToken.Line)
 	}
-	if p.nextTokenType(token.ASSIGN) {
+	operator, compound := compoundOperators[p.readToken.Type]
+	if p.nextTokenType(token.ASSIGN) || compound {
 		tok := p.nowToken
 		name := &ast.Identifier{Token: tok, Value: p.nowToken.Literal}
+		p.checkConst(name)
+		p.checkScope(name)
 		p.nextToken()
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 		if p.readToken.Type == token.SEMICOLON {
 			p.nextToken()
 		}
-		return &ast.Assign{Token: tok, Name: name, Value: value}
+		return &ast.Assign{Token: tok, Name: name, Operator: operator, Value: value}
 	}
-	return &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	ident := &ast.Identifier{Token: p.nowToken, Value: p.nowToken.Literal}
+	p.checkScope(ident)
+	return ident
 }
 
 //parse Intenger
 func (p *Parser) parseInt() ast.Expression {
 	inted, err := strconv.ParseInt(p.nowToken.Literal, 10, 0)
 	if err != nil {
+		//too big for int64
+		if bigval, ok := new(big.Int).SetString(p.nowToken.Literal, 10); ok {
+			return &ast.Int{Token: p.nowToken, Big: bigval}
+		}
 		p.setError(111, p.nowToken.Line, p.nowToken.Literal)
 		return nil
 	}
@@ -417,6 +882,11 @@ func (p *Parser) parseBool() ast.Expression {
 	return &ast.Bool{Token: p.nowToken, Value: p.nowTokenType(token.TRUE)}
 }
 
+//parse nil
+func (p *Parser) parseNil() ast.Expression {
+	return &ast.Nil{Token: p.nowToken}
+}
+
 //parse String Literal
 func (p *Parser) parseString() ast.Expression {
 	return &ast.String{Token: p.nowToken, Value: p.nowToken.Literal}
@@ -453,6 +923,15 @@ func (p *Parser) parseInfix(left ast.Expression) ast.Expression {
 	return ex
 }
 
+//parse Range Expression(start..end)
+func (p *Parser) parseRange(start ast.Expression) ast.Expression {
+	rg := &ast.Range{Token: p.nowToken, Start: start}
+	level := p.nowTokenPriority()
+	p.nextToken()
+	rg.End = p.parseExpression(level)
+	return rg
+}
+
 //expect next token and error check
 func (p *Parser) expect(t token.TokenType) bool {
 	if p.readToken.Type == t {
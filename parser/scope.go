@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/hmwri/peridot/ast"
+	"github.com/hmwri/peridot/errorwords"
+)
+
+//scope names defined in a block(used for const check and block scope warnings)
+type scope struct {
+	//consts names defined by const
+	consts map[string]bool
+	//names names defined in this block(value is the line)
+	names map[string]int
+	//closed names defined in inner blocks which are already closed(value is the line)
+	closed map[string]int
+	//function If true,this block is function body
+	function bool
+	//tail If true,value returned in this block is returned by function as it is(tail position)
+	tail bool
+}
+
+//newScope make scope and define names in it
+func newScope(function bool, names ...*ast.Identifier) *scope {
+	s := &scope{consts: map[string]bool{}, names: map[string]int{}, closed: map[string]int{}, function: function, tail: function}
+	for _, name := range names {
+		if name != nil {
+			s.names[name.Value] = name.Token.Line
+		}
+	}
+	return s
+}
+
+//nowScope Get scope of now parsing block
+func (p *Parser) nowScope() *scope {
+	return p.scopes[len(p.scopes)-1]
+}
+
+//openScope start new block scope
+func (p *Parser) openScope(s *scope) {
+	p.scopes = append(p.scopes, s)
+}
+
+//closeScope end block scope.Names defined in it are remembered by outer scope(except function)
+func (p *Parser) closeScope() {
+	s := p.nowScope()
+	p.scopes = p.scopes[:len(p.scopes)-1]
+	if s.function {
+		return
+	}
+	out := p.nowScope()
+	for name, line := range s.names {
+		out.closed[name] = line
+	}
+	for name, line := range s.closed {
+		if _, found := out.closed[name]; !found {
+			out.closed[name] = line
+		}
+	}
+}
+
+//declare define name in now scope.If same name is defined outside block,set warning
+func (p *Parser) declare(name *ast.Identifier) {
+	for i := len(p.scopes) - 2; i >= 0; i-- {
+		if p.scopes[i+1].function {
+			break
+		}
+		if _, found := p.scopes[i].names[name.Value]; found {
+			p.setWarning(171, name.Token.Line, name.Value, name.Value, name.Value)
+			break
+		}
+	}
+	p.nowScope().names[name.Value] = name.Token.Line
+}
+
+//checkScope set warning if name is only defined in closed block
+func (p *Parser) checkScope(name *ast.Identifier) {
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if _, found := p.scopes[i].names[name.Value]; found {
+			return
+		}
+		if line, found := p.scopes[i].closed[name.Value]; found {
+			p.setWarning(170, name.Token.Line, name.Value, line)
+			return
+		}
+	}
+}
+
+//checkConst set error if name is constant defined in now scope
+func (p *Parser) checkConst(name *ast.Identifier) {
+	if p.nowScope().consts[name.Value] {
+		p.setError(160, name.Token.Line, name.Value)
+	}
+}
+
+//setWarning set warning(the program can be run)
+func (p *Parser) setWarning(code int, params ...interface{}) {
+	message := fmt.Sprintf(errorwords.Err[code], params...)
+	line := params[0].(int)
+	p.warnings = append(p.warnings, Err{Message: message, Line: line})
+}
+
+//GetWarning get warnings
+func (p *Parser) GetWarning() []Err {
+	return p.warnings
+}
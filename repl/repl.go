@@ -29,7 +29,7 @@ func Open() {
 	fmt.Printf("%v\n", op)
 	fmt.Printf("PeriDot " + info.Version + info.CheckVersion() + "\n")
 	fmt.Println("ぜひフィードバックにご協力ください!リンク:https://forms.gle/Cca4668Tah7x2o5YA\n")
-	fmt.Printf("%vさんようこそ！ここでは対話式プログラム実行ができます！\n終了：Q!, ログ(実行過程)表示:LOG!\n>>", user.Username)
+	fmt.Printf("%vさんようこそ！ここでは対話式プログラム実行ができます！\n終了：Q!, ログ(実行過程)表示:LOG!, 分数モード:EXACT!\n>>", user.Username)
 	scanner := bufio.NewScanner(os.Stdin)
 	env := object.NewEnv()
 	indent := 0
@@ -51,6 +51,17 @@ func Open() {
 			fmt.Printf(">>")
 			continue
 		}
+		if context == "EXACT!" {
+			if eval.Exact {
+				fmt.Printf("分数モードをオフにしました！\n")
+				eval.Exact = false
+			} else {
+				fmt.Printf("分数モードをオンにしました！(7 / 2 が 7/2 になります)\n")
+				eval.Exact = true
+			}
+			fmt.Printf(">>")
+			continue
+		}
 		if plusInd := strings.Count(context, "{"); plusInd > 0 {
 			if indent > -1 {
 				indent += plusInd
@@ -84,6 +95,7 @@ func writeMode(t string, env *object.Env, logswitch bool) {
 	l := lexer.New(t)
 	p := parser.New(l)
 	program := p.Parse()
+	Checkwarning(p)
 	if !Checkerror(p) {
 		eval := eval.Eval(program, env)
 		if logswitch {
@@ -94,7 +106,7 @@ func writeMode(t string, env *object.Env, logswitch bool) {
 		if len(errorwords.Errp.Error) != 0 {
 			fmt.Printf("\x1b[31m(´；ω；)<おっと!:%v\x1b[0m\n", deleteLine(errorwords.Errp.Error[0].Message))
 		} else {
-			if eval != nil {
+			if eval != object.NULL {
 				fmt.Printf("(≧▽≦)Answer:")
 				fmt.Println(eval.Inspect())
 			}
@@ -114,6 +126,18 @@ func Checkerror(p *parser.Parser) bool {
 	}
 	return true
 }
+
+//Checkwarning print warnings(the program is run)
+func Checkwarning(p *parser.Parser) {
+	w := p.GetWarning()
+	if len(w) == 0 {
+		return
+	}
+	fmt.Printf("(・ω・)<注意が%v個あります\n", len(w))
+	for _, v := range w {
+		fmt.Printf("\x1b[33m%v\x1b[0m\n", deleteLine(v.Message))
+	}
+}
 func deleteLine(str string) string {
 	return string([]rune(str)[5:])
 }
@@ -0,0 +1,110 @@
+<< 配列(リスト)を操作する関数 >>
+
+<< map(arr, f)
+配列の各要素にfを適用した新しい配列を返します
+例: list.map([1, 2, 3], func(x) { return x * 2 }) -> [2, 4, 6] >>
+func map(arr, f) {
+  make result = []
+  loop x in arr {
+    ADD(result, f(x))
+  }
+  return result
+}
+
+<< filter(arr, f)
+f(要素)がtrueになる要素だけを集めた新しい配列を返します
+例: list.filter([1, 2, 3, 4], func(x) { return x % 2 == 0 }) -> [2, 4] >>
+func filter(arr, f) {
+  make result = []
+  loop x in arr {
+    if f(x) {
+      ADD(result, x)
+    }
+  }
+  return result
+}
+
+<< reduce(arr, f, init)
+initから始めて、f(それまでの結果, 要素)を順に計算した結果を返します
+例: list.reduce([1, 2, 3], func(a, x) { return a + x }, 0) -> 6 >>
+func reduce(arr, f, init) {
+  make acc = init
+  loop x in arr {
+    acc = f(acc, x)
+  }
+  return acc
+}
+
+<< contains(arr, value)
+配列にvalueが含まれていればtrueを返します >>
+func contains(arr, value) {
+  return indexOf(arr, value) != -1
+}
+
+<< indexOf(arr, value)
+valueが最初に現れる添字を返します。見つからなければ-1を返します >>
+func indexOf(arr, value) {
+  loop i, x in arr {
+    if x == value {
+      return i
+    }
+  }
+  return -1
+}
+
+<< copy(arr)
+配列をコピーした新しい配列を返します >>
+func copy(arr) {
+  make result = []
+  loop x in arr {
+    ADD(result, x)
+  }
+  return result
+}
+
+<< reverse(arr)
+順番を逆にした新しい配列を返します
+例: list.reverse([1, 2, 3]) -> [3, 2, 1] >>
+func reverse(arr) {
+  make result = []
+  make i = SIZE(arr) - 1
+  loop i >= 0 {
+    ADD(result, arr[i])
+    i -= 1
+  }
+  return result
+}
+
+<< concat(a, b)
+2つの配列をつなげた新しい配列を返します >>
+func concat(a, b) {
+  make result = copy(a)
+  loop x in b {
+    ADD(result, x)
+  }
+  return result
+}
+
+<< unique(arr)
+重複を取り除いた新しい配列を返します(最初に現れた順) >>
+func unique(arr) {
+  make result = []
+  loop x in arr {
+    if !contains(result, x) {
+      ADD(result, x)
+    }
+  }
+  return result
+}
+
+<< count(arr, f)
+f(要素)がtrueになる要素の数を返します >>
+func count(arr, f) {
+  make n = 0
+  loop x in arr {
+    if f(x) {
+      n += 1
+    }
+  }
+  return n
+}
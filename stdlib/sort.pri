@@ -0,0 +1,127 @@
+<< 並べ替え(ソート)のアルゴリズム。どれも小さい順に並べた新しい配列を返します >>
+
+import "list"
+
+<< bubbleSort(arr)
+となり同士を比べて、大きいほうを後ろへ送ることをくり返します >>
+func bubbleSort(arr) {
+  make a = list.copy(arr)
+  make n = SIZE(a)
+  loop i in 1..n {
+    make swapped = false
+    make j = 0
+    loop j < n - i {
+      if a[j] > a[j + 1] {
+        make tmp = a[j]
+        a[j] = a[j + 1]
+        a[j + 1] = tmp
+        swapped = true
+      }
+      j += 1
+    }
+    if !swapped {
+      stop
+    }
+  }
+  return a
+}
+
+<< selectionSort(arr)
+残りの中で一番小さい値を探して、前から順に置いていきます >>
+func selectionSort(arr) {
+  make a = list.copy(arr)
+  make n = SIZE(a)
+  make i = 0
+  loop i < n - 1 {
+    make min = i
+    make j = i + 1
+    loop j < n {
+      if a[j] < a[min] {
+        min = j
+      }
+      j += 1
+    }
+    make tmp = a[i]
+    a[i] = a[min]
+    a[min] = tmp
+    i += 1
+  }
+  return a
+}
+
+<< insertionSort(arr)
+前のほうを並んだ状態に保ちながら、1つずつ正しい場所に差し込みます >>
+func insertionSort(arr) {
+  make a = list.copy(arr)
+  make i = 1
+  loop i < SIZE(a) {
+    make x = a[i]
+    make j = i - 1
+    loop j >= 0 {
+      if a[j] <= x {
+        stop
+      }
+      a[j + 1] = a[j]
+      j -= 1
+    }
+    a[j + 1] = x
+    i += 1
+  }
+  return a
+}
+
+<< mergeSort(arr)
+半分に分けてそれぞれを並べ替え、小さい順に合わせます(再帰) >>
+func mergeSort(arr) {
+  if SIZE(arr) <= 1 {
+    return list.copy(arr)
+  }
+  make mid = (SIZE(arr) - SIZE(arr) % 2) / 2
+  make left = mergeSort(SLICE(arr, 0, mid))
+  make right = mergeSort(SLICE(arr, mid))
+  make result = []
+  make i = 0
+  make j = 0
+  loop i < SIZE(left) and j < SIZE(right) {
+    if left[i] <= right[j] {
+      ADD(result, left[i])
+      i += 1
+    } else {
+      ADD(result, right[j])
+      j += 1
+    }
+  }
+  loop i < SIZE(left) {
+    ADD(result, left[i])
+    i += 1
+  }
+  loop j < SIZE(right) {
+    ADD(result, right[j])
+    j += 1
+  }
+  return result
+}
+
+<< quickSort(arr)
+基準(ピボット)より小さいもの・大きいものに分けて、それぞれを並べ替えます(再帰) >>
+func quickSort(arr) {
+  if SIZE(arr) <= 1 {
+    return list.copy(arr)
+  }
+  make pivot = arr[0]
+  make smaller = []
+  make larger = []
+  loop i, x in arr {
+    if i == 0 {
+      next
+    }
+    if x < pivot {
+      ADD(smaller, x)
+    } else {
+      ADD(larger, x)
+    }
+  }
+  make result = quickSort(smaller)
+  ADD(result, pivot)
+  return list.concat(result, quickSort(larger))
+}
@@ -0,0 +1,71 @@
+<< かんたんな統計の関数 >>
+
+import "sort"
+
+<< sum(arr)
+合計を返します >>
+func sum(arr) {
+  make total = 0
+  loop x in arr {
+    total += x
+  }
+  return total
+}
+
+<< mean(arr)
+平均を返します >>
+func mean(arr) {
+  return sum(arr) / SIZE(arr)
+}
+
+<< max(arr)
+一番大きい値を返します >>
+func max(arr) {
+  make result = arr[0]
+  loop x in arr {
+    if x > result {
+      result = x
+    }
+  }
+  return result
+}
+
+<< min(arr)
+一番小さい値を返します >>
+func min(arr) {
+  make result = arr[0]
+  loop x in arr {
+    if x < result {
+      result = x
+    }
+  }
+  return result
+}
+
+<< median(arr)
+中央値(小さい順に並べたときの真ん中の値)を返します >>
+func median(arr) {
+  make a = sort.mergeSort(arr)
+  make n = SIZE(a)
+  if n % 2 == 1 {
+    return a[(n - 1) / 2]
+  }
+  return (a[n / 2 - 1] + a[n / 2]) / 2
+}
+
+<< variance(arr)
+分散(平均からのずれの2乗の平均)を返します >>
+func variance(arr) {
+  make m = mean(arr)
+  make total = 0
+  loop x in arr {
+    total += (x - m) * (x - m)
+  }
+  return total / SIZE(arr)
+}
+
+<< stddev(arr)
+標準偏差(分散の平方根)を返します >>
+func stddev(arr) {
+  return ROOT(variance(arr))
+}
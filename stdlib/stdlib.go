@@ -0,0 +1,77 @@
+package stdlib
+
+import (
+	"embed"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//files standard library modules(import "list" etc.)
+//
+//go:embed *.pri
+var files embed.FS
+
+//Doc document of function(comment written just before func)
+type Doc struct {
+	Name   string
+	Params string
+	Text   string
+}
+
+var (
+	//commentRe << comment >>
+	commentRe = regexp.MustCompile(`(?s)<<(.*?)>>`)
+	//funcRe func name(params) just after comment
+	funcRe = regexp.MustCompile(`^\s*func\s+([^\s(]+)\s*\(([^)]*)\)`)
+)
+
+//Source Get source of module(name is without .pri)
+func Source(name string) (string, bool) {
+	src, err := files.ReadFile(name + ".pri")
+	if err != nil {
+		return "", false
+	}
+	return string(src), true
+}
+
+//Names Get names of all modules
+func Names() []string {
+	entries, _ := files.ReadDir(".")
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".pri"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+//Docs Extract documents of functions from source.Comment(<< >>) written just before func is document
+func Docs(src string) []Doc {
+	docs := []Doc{}
+	for _, m := range commentRe.FindAllStringSubmatchIndex(src, -1) {
+		fn := funcRe.FindStringSubmatch(src[m[1]:])
+		if fn == nil {
+			continue
+		}
+		text := strings.TrimSpace(src[m[2]:m[3]])
+		//first line "name(params)" is same as func
+		if lines := strings.SplitN(text, "\n", 2); strings.HasPrefix(lines[0], fn[1]+"(") {
+			text = ""
+			if len(lines) == 2 {
+				text = strings.TrimSpace(lines[1])
+			}
+		}
+		docs = append(docs, Doc{Name: fn[1], Params: strings.TrimSpace(fn[2]), Text: text})
+	}
+	return docs
+}
+
+//Summary Get first comment of source(description of module)
+func Summary(src string) string {
+	m := commentRe.FindStringSubmatchIndex(src)
+	if m == nil || funcRe.MatchString(src[m[1]:]) {
+		return ""
+	}
+	return strings.TrimSpace(src[m[2]:m[3]])
+}
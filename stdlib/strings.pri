@@ -0,0 +1,128 @@
+<< 文字列を操作する関数(文字の位置は1から数えます) >>
+
+<< repeat(s, n)
+sをn回くり返した文字列を返します
+例: strings.repeat("ab", 3) -> "ababab" >>
+func repeat(s, n) {
+  make result = ""
+  loop n {
+    result += s
+  }
+  return result
+}
+
+<< reverse(s)
+文字の順番を逆にした文字列を返します
+例: strings.reverse("abc") -> "cba" >>
+func reverse(s) {
+  make result = ""
+  loop ch in s {
+    result = ch + result
+  }
+  return result
+}
+
+<< startsWith(s, prefix)
+sがprefixで始まっていればtrueを返します >>
+func startsWith(s, prefix) {
+  if SIZE(prefix) > SIZE(s) {
+    return false
+  }
+  if SIZE(prefix) == 0 {
+    return true
+  }
+  return SLICE(s, 1, SIZE(prefix)) == prefix
+}
+
+<< endsWith(s, suffix)
+sがsuffixで終わっていればtrueを返します >>
+func endsWith(s, suffix) {
+  if SIZE(suffix) > SIZE(s) {
+    return false
+  }
+  if SIZE(suffix) == 0 {
+    return true
+  }
+  return SLICE(s, SIZE(s) - SIZE(suffix) + 1) == suffix
+}
+
+<< indexOf(s, sub)
+subが最初に現れる位置(1から)を返します。見つからなければ0を返します >>
+func indexOf(s, sub) {
+  if SIZE(sub) == 0 {
+    return 1
+  }
+  make i = 1
+  loop i + SIZE(sub) - 1 <= SIZE(s) {
+    if SLICE(s, i, i + SIZE(sub) - 1) == sub {
+      return i
+    }
+    i += 1
+  }
+  return 0
+}
+
+<< contains(s, sub)
+sにsubが含まれていればtrueを返します >>
+func contains(s, sub) {
+  return indexOf(s, sub) != 0
+}
+
+<< split(s, sep)
+sをsepで区切った文字列の配列を返します
+例: strings.split("a,b,c", ",") -> ["a", "b", "c"] >>
+func split(s, sep) {
+  make result = []
+  make rest = s
+  make i = indexOf(rest, sep)
+  loop i != 0 and SIZE(sep) > 0 {
+    if i == 1 {
+      ADD(result, "")
+    } else {
+      ADD(result, SLICE(rest, 1, i - 1))
+    }
+    if i + SIZE(sep) > SIZE(rest) {
+      rest = ""
+    } else {
+      rest = SLICE(rest, i + SIZE(sep))
+    }
+    i = indexOf(rest, sep)
+  }
+  ADD(result, rest)
+  return result
+}
+
+<< join(arr, sep)
+配列の要素をsepでつなげた文字列を返します
+例: strings.join(["a", "b"], "-") -> "a-b" >>
+func join(arr, sep) {
+  make result = ""
+  loop i, x in arr {
+    if i > 0 {
+      result += sep
+    }
+    result += FORMAT("{}", x)
+  }
+  return result
+}
+
+<< padLeft(s, width, ch = " ")
+長さがwidthになるまで左にchを足した文字列を返します
+例: strings.padLeft("7", 3, "0") -> "007" >>
+func padLeft(s, width, ch = " ") {
+  make result = s
+  loop SIZE(result) < width {
+    result = ch + result
+  }
+  return result
+}
+
+<< padRight(s, width, ch = " ")
+長さがwidthになるまで右にchを足した文字列を返します >>
+func padRight(s, width, ch = " ") {
+  make result = s
+  loop SIZE(result) < width {
+    result += ch
+  }
+  return result
+}
@@ -21,6 +21,7 @@ const (
 	FLOAT  = "FLOAT"
 	TRUE   = "TRUE"
 	FALSE  = "FALSE"
+	NIL    = "NIL"
 	STRING = "STRING"
 
 	//Operators
@@ -31,10 +32,21 @@ const (
 	SLASH    = "/"
 	PERCENT  = "%"
 	EXCLA    = "!"
+	//Compound assignment operators
+	PLUSASSIGN     = "+="
+	MINUSASSIGN    = "-="
+	ASTERISKASSIGN = "*="
+	SLASHASSIGN    = "/="
+	PERCENTASSIGN  = "%="
 
 	//Delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
+	ARROW     = "=>"
+	DOTDOT    = ".."
+	ELLIPSIS  = "..."
+	DOT       = "."
 
 	//PARENs
 	LPAREN   = "("
@@ -56,26 +68,45 @@ const (
 	//Keywords
 	FUNCTION = "FUNCTION"
 	MAKE     = "MAKE"
+	CONST    = "CONST"
+	TYPE     = "TYPE"
+	IMPORT   = "IMPORT"
+	AS       = "AS"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
 	STOP     = "STOP"
+	NEXT     = "NEXT"
 	LOOP     = "LOOP"
+	IN       = "IN"
+	MATCH    = "MATCH"
+	TRY      = "TRY"
+	CATCH    = "CATCH"
 )
 
 //Keywords keywords(fn,let etc...)
 var Keywords = map[string]TokenType{
 	"func":   FUNCTION,
 	"make":   MAKE,
+	"const":  CONST,
+	"type":   TYPE,
+	"import": IMPORT,
+	"as":     AS,
 	"if":     IF,
 	"else":   ELSE,
 	"return": RETURN,
 	"stop":   STOP,
+	"next":   NEXT,
 	"true":   TRUE,
 	"false":  FALSE,
+	"nil":    NIL,
 	"and":    AND,
 	"or":     OR,
 	"loop":   LOOP,
+	"in":     IN,
+	"match":  MATCH,
+	"try":    TRY,
+	"catch":  CATCH,
 }
 
 //IsKeywords If keywords,return the tokentype,else,return IDENT